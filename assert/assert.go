@@ -6,11 +6,13 @@
 //
 // These functions are no-ops with minimal performance overhead when called outside of the Antithesis environment. However, if the environment variable ANTITHESIS_SDK_LOCAL_OUTPUT is set, these functions will log to the file pointed to by that variable using a structured JSON format defined [here]. This allows you to make use of the Antithesis assertions package in your regular testing, or even in production. In particular, very few assertions frameworks offer a convenient way to define [Sometimes assertions], but they can be quite useful even outside Antithesis.
 //
+// Outside of Antithesis, [Summary] computes a pass or fail verdict for every test property evaluated by the program, and [ReportSummary] reports it. If the environment variable ANTITHESIS_SDK_LOCAL_SUMMARY is set, the summary is reported automatically when the program is interrupted or terminated, but not when main returns: defer ReportSummary at the start of main to report it then.
+//
 // Each function in this package takes a parameter called message, which is a human readable identifier used to aggregate assertions. Antithesis generates one test property per unique message and this test property will be named "<message>" in the [triage report].
 //
 // This test property either passes or fails, which depends upon the evaluation of every assertion that shares its message. Different assertions in different parts of the code should have different message, but the same assertion should always have the same message even if it is moved to a different file.
 //
-// Each function also takes a parameter called details, which is a key-value map of optional additional information provided by the user to add context for assertion failures. The information that is logged will appear in the [triage report], under the details section of the corresponding property. Normally the values passed to details are evaluated at runtime.
+// Each function also takes a parameter called details, which is a key-value map of optional additional information provided by the user to add context for assertion failures. The information that is logged will appear in the [triage report], under the details section of the corresponding property. Normally the values passed to details are evaluated at runtime. Use [LazyDetails] to defer computing expensive details until they are actually reported. Use [WithDetails] with the Ctx variants of the assertion functions, such as [AlwaysCtx], to add details carried by a context.Context, such as the IDs of the request being handled. Types can control how they appear in details by implementing [Detailer]. Use [RedactKeys] and [RedactValues] to keep secrets out of the details that are reported.
 //
 // [Antithesis Go SDK]: https://antithesis.com/docs/using_antithesis/sdk/go/
 // [Antithesis platform]: https://antithesis.com
@@ -26,7 +28,6 @@ package assert
 
 import (
 	"encoding/json"
-	"fmt"
 )
 
 type assertInfo struct {
@@ -39,6 +40,16 @@ type assertInfo struct {
 	Hit         bool           `json:"hit"`
 	MustHit     bool           `json:"must_hit"`
 	Condition   bool           `json:"condition"`
+	normalized  bool
+}
+
+// normalizeDetails resolves and normalizes the details ahead of marshaling, so that
+// the methods of the values in them are not called while holding trackerInfoMutex
+func (ai *assertInfo) normalizeDetails() {
+	if ai.Details != nil && !ai.normalized {
+		ai.Details = normalizeMap(resolveDetails(ai.Details))
+	}
+	ai.normalized = true
 }
 
 // Create a custom json marshaler for assertInfo so that we can force Errors to be marshaled with their error details.
@@ -47,58 +58,12 @@ type assertInfo struct {
 func (f assertInfo) MarshalJSON() ([]byte, error) {
 	type alias assertInfo // prevent infinite recursion
 	a := alias(f)
-	if a.Details != nil {
-		a.Details = normalizeMap(a.Details)
+	if a.Details != nil && !a.normalized {
+		a.Details = normalizeMap(resolveDetails(a.Details))
 	}
 	return json.Marshal(a)
 }
 
-type jsonError struct {
-	innerError error
-}
-
-func (e jsonError) MarshalJSON() ([]byte, error) {
-	// Marshal this as the debug output string instead of e.Error(). These should be equivalent, but Sprintf correctly
-	// handles nil values for us (which otherwise are annoying to defend against due to this - https://go.dev/doc/faq#nil_error)
-	return json.Marshal(fmt.Sprintf("%+v", e.innerError))
-}
-
-// Recursively replace any `error` with jsonError while doing a deep copy.
-// Most of the logic is in the normalize method below. This method exists to localize the type assertions
-// and provide a function that takes in/out a map instead of any.
-func normalizeMap(v map[string]any) map[string]any {
-	return normalize(v).(map[string]any)
-}
-
-func normalize(input any) any {
-	// This switch will miss some cases (pointers, structs, non-any types), but should catch a very large proportion of real error interfaces
-	// in real details objects. We can augment this if we find other cases common enough to support.
-	switch inputTyped := input.(type) {
-	case error:
-		// Check if the underlying error implements json.Marshaler, so that if the error
-		// already knows who to marshal itself, we don't override that.
-		if _, ok := inputTyped.(json.Marshaler); ok {
-			return inputTyped
-		} else {
-			return jsonError{inputTyped}
-		}
-	case map[string]any:
-		out := make(map[string]any, len(inputTyped))
-		for k, v := range inputTyped {
-			out[k] = normalize(v)
-		}
-		return out
-	case []any:
-		out := make([]any, len(inputTyped))
-		for i := range inputTyped {
-			out[i] = normalize(inputTyped[i])
-		}
-		return out
-	default:
-		return input
-	}
-}
-
 type wrappedAssertInfo struct {
 	A *assertInfo `json:"antithesis_assert"`
 }
@@ -129,35 +94,35 @@ const (
 
 // Always asserts that condition is true every time this function is called, and that it is called at least once. The corresponding test property will be viewable in the Antithesis SDK: Always group of your triage report.
 func Always(condition bool, message string, details map[string]any) {
-	locationInfo := newLocationInfo(offsetAPICaller)
+	locationInfo := callerLocation(offsetAPICaller)
 	id := makeKey(message, locationInfo)
 	assertImpl(condition, message, details, locationInfo, wasHit, mustBeHit, universalTest, alwaysDisplay, id)
 }
 
 // AlwaysOrUnreachable asserts that condition is true every time this function is called. The corresponding test property will pass if the assertion is never encountered (unlike Always assertion types). This test property will be viewable in the “Antithesis SDK: Always” group of your triage report.
 func AlwaysOrUnreachable(condition bool, message string, details map[string]any) {
-	locationInfo := newLocationInfo(offsetAPICaller)
+	locationInfo := callerLocation(offsetAPICaller)
 	id := makeKey(message, locationInfo)
 	assertImpl(condition, message, details, locationInfo, wasHit, optionallyHit, universalTest, alwaysOrUnreachableDisplay, id)
 }
 
 // Sometimes asserts that condition is true at least one time that this function was called. (If the assertion is never encountered, the test property will therefore fail.) This test property will be viewable in the “Antithesis SDK: Sometimes” group.
 func Sometimes(condition bool, message string, details map[string]any) {
-	locationInfo := newLocationInfo(offsetAPICaller)
+	locationInfo := callerLocation(offsetAPICaller)
 	id := makeKey(message, locationInfo)
 	assertImpl(condition, message, details, locationInfo, wasHit, mustBeHit, existentialTest, sometimesDisplay, id)
 }
 
 // Unreachable asserts that a line of code is never reached. The corresponding test property will fail if this function is ever called. (If it is never called the test property will therefore pass.) This test property will be viewable in the “Antithesis SDK: Reachablity assertions” group.
 func Unreachable(message string, details map[string]any) {
-	locationInfo := newLocationInfo(offsetAPICaller)
+	locationInfo := callerLocation(offsetAPICaller)
 	id := makeKey(message, locationInfo)
 	assertImpl(false, message, details, locationInfo, wasHit, optionallyHit, reachabilityTest, unreachableDisplay, id)
 }
 
 // Reachable asserts that a line of code is reached at least once. The corresponding test property will pass if this function is ever called. (If it is never called the test property will therefore fail.) This test property will be viewable in the “Antithesis SDK: Reachablity assertions” group.
 func Reachable(message string, details map[string]any) {
-	locationInfo := newLocationInfo(offsetAPICaller)
+	locationInfo := callerLocation(offsetAPICaller)
 	id := makeKey(message, locationInfo)
 	assertImpl(true, message, details, locationInfo, wasHit, mustBeHit, reachabilityTest, reachableDisplay, id)
 }
@@ -184,17 +149,29 @@ func assertImpl(cond bool, message string, details map[string]any,
 ) {
 	trackerEntry := assertTracker.getTrackerEntry(id, loc.Filename, loc.Classname)
 
-	// Always grab the Filename and Classname captured when the trackerEntry was established
-	// This provides the consistency needed between instrumentation-time and runtime
-	if loc.Filename != trackerEntry.Filename {
-		loc.Filename = trackerEntry.Filename
+	// Repeated outcomes are only counted, without building an assertInfo
+	if hit && trackerEntry.countIfEmitted(cond) {
+		return
 	}
 
-	if loc.Classname != trackerEntry.Classname {
-		loc.Classname = trackerEntry.Classname
-	}
+	trackerEntry.emit(newAssertInfo(trackerEntry, cond, message, details, loc, hit, mustHit, assertType, displayType, id))
+}
+
+func newAssertInfo(trackerEntry *trackerInfo,
+	cond bool, message string, details map[string]any,
+	loc *locationInfo,
+	hit bool, mustHit bool,
+	assertType string, displayType string,
+	id string,
+) *assertInfo {
+	// Always grab the Filename and Classname captured when the trackerEntry was established
+	// This provides the consistency needed between instrumentation-time and runtime
+	// The loc may be shared by every call from the same call site, so it is copied
+	emitLoc := *loc
+	emitLoc.Filename = trackerEntry.Filename
+	emitLoc.Classname = trackerEntry.Classname
 
-	aI := &assertInfo{
+	return &assertInfo{
 		Hit:         hit,
 		MustHit:     mustHit,
 		AssertType:  assertType,
@@ -202,11 +179,9 @@ func assertImpl(cond bool, message string, details map[string]any,
 		Message:     message,
 		Condition:   cond,
 		Id:          id,
-		Location:    loc,
+		Location:    &emitLoc,
 		Details:     details,
 	}
-
-	trackerEntry.emit(aI)
 }
 
 func makeKey(message string, _ *locationInfo) string {
@@ -2,11 +2,41 @@
 
 package assert
 
+import (
+	"regexp"
+	"time"
+)
+
 func Always(condition bool, message string, details map[string]any)              {}
 func AlwaysOrUnreachable(condition bool, message string, details map[string]any) {}
 func Sometimes(condition bool, message string, details map[string]any)           {}
 func Unreachable(message string, details map[string]any)                         {}
 func Reachable(message string, details map[string]any)                           {}
+func LazyDetails(fn func() map[string]any) map[string]any                        { return nil }
+func SetDetailsLimits(maxDepth, maxBytes int)                                    {}
+func RedactKeys(patterns ...string) error                                        { return nil }
+func RedactValues(patterns ...*regexp.Regexp)                                    {}
+func Eventually(message string, timeout time.Duration, predicate func() bool, details map[string]any) {
+}
+func RegisterInvariant(message string, check func() (bool, map[string]any)) {}
+func CheckInvariants()                                                      {}
+func SetInvariantInterval(interval time.Duration)                           {}
+func AlwaysHappensBefore(first, then, keyField string, message string, details map[string]any) {
+}
+
+// The panic helpers keep their effect on control flow when the SDK is disabled
+func RecoverAndReport(message string, details map[string]any) {
+	recover()
+}
+func ReportPanic(message string, details map[string]any) {}
+func EnableCrashReports() error                          { return nil }
+func Go(message string, fn func(), details map[string]any) {
+	go func() {
+		defer func() { recover() }()
+		fn()
+	}()
+}
+
 func AssertRaw(cond bool, message string, details map[string]any,
 	classname, funcname, filename string, line int,
 	hit bool, mustHit bool,
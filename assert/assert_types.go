@@ -28,3 +28,47 @@ func NewNamedBool(first string, second bool) *NamedBool {
 	}
 	return &p
 }
+
+// Verdict is the outcome of a test property, as reported by [Summary].
+type Verdict string
+
+const (
+	// The property passed
+	VerdictPassed Verdict = "passed"
+	// An Always, AlwaysOrUnreachable or Unreachable assertion was violated
+	VerdictFailed Verdict = "failed"
+	// A property that must be hit, such as Always, Sometimes or Reachable, was never evaluated
+	VerdictNeverHit Verdict = "never hit"
+	// A Sometimes assertion was evaluated, but its condition was never true
+	VerdictNeverTrue Verdict = "never true"
+)
+
+// PropertySummary describes the local outcome of one test property, aggregated over every assertion that shares its message.
+type PropertySummary struct {
+	Message     string  `json:"message"`
+	DisplayType string  `json:"display_type"`
+	Verdict     Verdict `json:"verdict"`
+	Classname   string  `json:"class"`
+	Funcname    string  `json:"function"`
+	Filename    string  `json:"file"`
+	Line        int     `json:"begin_line"`
+	PassCount   int     `json:"pass_count"`
+	FailCount   int     `json:"fail_count"`
+}
+
+// Passed reports whether the verdict of the property is [VerdictPassed].
+func (s PropertySummary) Passed() bool {
+	return s.Verdict == VerdictPassed
+}
+
+// Detailer is implemented by types that control how they appear in the details of an assertion. When a value in details implements Detailer, it is replaced with the result of AntithesisDetails, which is then reported like any other value in details.
+//
+// Values that do not implement Detailer are reported using, in order of preference, their json.Marshaler, error, encoding.TextMarshaler, slog.LogValuer or fmt.Stringer implementation, and otherwise as encoding/json would marshal them. The fmt.Stringer implementation of a struct, or of a pointer to one, is not used, so that structs such as protocol buffer messages are reported field by field.
+type Detailer interface {
+	AntithesisDetails() any
+}
+
+// Redactable is implemented by types that decide for themselves whether their values are redacted from details and events. When AntithesisRedact returns true, the value is always reported as "[redacted]". When it returns false, the patterns set with RedactKeys and RedactValues are not applied to the value, or to anything inside it.
+type Redactable interface {
+	AntithesisRedact() bool
+}
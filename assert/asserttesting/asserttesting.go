@@ -0,0 +1,137 @@
+//go:build !no_antithesis_sdk
+
+// Package asserttesting reports the assertions made with the [assert] package as failures of an ordinary go test. It is part of the [Antithesis Go SDK], which enables Go applications to integrate with the [Antithesis platform].
+//
+// Code that uses the assert package is frequently exercised by regular unit tests as well as in Antithesis. Outside of Antithesis, failing assertions are only logged, so a unit test would not notice them. Call Attach at the start of a test to make failing assertions fail that test:
+//
+//	func TestTransfer(t *testing.T) {
+//		asserttesting.Attach(t)
+//		...
+//	}
+//
+// While a test is attached, any Always, AlwaysOrUnreachable or Unreachable assertion that fails, in any goroutine, is reported with t.Errorf. When the test finishes, every Sometimes and Reachable property that was registered or evaluated but never satisfied is also reported with t.Errorf.
+//
+// Assertions are reported once per property per test. Attach resets the SDK's record of which assertions have already been reported, so each test starts clean. Tests that call Attach should not run in parallel with each other, as failures cannot be attributed to a particular test and are reported to every attached test.
+//
+// [Antithesis Go SDK]: https://antithesis.com/docs/using_antithesis/sdk/go/
+// [Antithesis platform]: https://antithesis.com
+package asserttesting
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/antithesishq/antithesis-sdk-go/internal"
+	"github.com/antithesishq/antithesis-sdk-go/sdk"
+)
+
+type location struct {
+	Classname string `json:"class"`
+	Funcname  string `json:"function"`
+	Filename  string `json:"file"`
+	Line      int    `json:"begin_line"`
+}
+
+type assertion struct {
+	Location    location        `json:"location"`
+	Details     json.RawMessage `json:"details"`
+	AssertType  string          `json:"assert_type"`
+	DisplayType string          `json:"display_type"`
+	Message     string          `json:"message"`
+	Hit         bool            `json:"hit"`
+	Condition   bool            `json:"condition"`
+}
+
+type wrappedAssertion struct {
+	A *assertion `json:"antithesis_assert"`
+}
+
+var (
+	attachMutex     sync.Mutex
+	attached        []testing.TB
+	previousHandler sdk.Handler
+)
+
+// Attach makes failing assertions fail t, for the duration of the test. See the package documentation for details.
+func Attach(t testing.TB) {
+	t.Helper()
+
+	attachMutex.Lock()
+	if len(attached) == 0 {
+		internal.ResetTrackers()
+		previousHandler = sdk.CurrentHandler()
+		sdk.SetHandler(sdk.MultiHandler(previousHandler, sdk.HandlerFunc(report)))
+	}
+	attached = append(attached, t)
+	attachMutex.Unlock()
+
+	t.Cleanup(func() {
+		detach(t)
+		checkUnsatisfied(t)
+	})
+}
+
+func detach(t testing.TB) {
+	attachMutex.Lock()
+	defer attachMutex.Unlock()
+	for i, tb := range attached {
+		if tb == t {
+			attached = append(attached[:i], attached[i+1:]...)
+			break
+		}
+	}
+	if len(attached) == 0 {
+		sdk.SetHandler(previousHandler)
+		previousHandler = nil
+	}
+}
+
+// report is the sdk.Handler that turns failing assertions into test failures
+func report(message string) {
+	var wrapped wrappedAssertion
+	if err := json.Unmarshal([]byte(message), &wrapped); err != nil || wrapped.A == nil {
+		return
+	}
+	a := wrapped.A
+	if !a.Hit || a.Condition || a.AssertType == "sometimes" {
+		return
+	}
+
+	attachMutex.Lock()
+	targets := append([]testing.TB{}, attached...)
+	attachMutex.Unlock()
+
+	for _, t := range targets {
+		t.Errorf("%s assertion failed: %q\n\tat %s:%d (%s.%s)\n\tdetails: %s",
+			a.DisplayType, a.Message,
+			a.Location.Filename, a.Location.Line, a.Location.Classname, a.Location.Funcname,
+			detailsText(a.Details))
+	}
+}
+
+// checkUnsatisfied reports the Sometimes and Reachable properties that never passed
+func checkUnsatisfied(t testing.TB) {
+	for _, p := range internal.Properties() {
+		if !p.MustHit || p.PassCount > 0 {
+			continue
+		}
+		if p.AssertType != "sometimes" && p.AssertType != "reachability" {
+			continue
+		}
+		if p.FailCount == 0 {
+			t.Errorf("%s assertion was never reached: %q\n\tat %s:%d (%s.%s)",
+				p.DisplayType, p.Message, p.Filename, p.Line, p.Classname, p.Funcname)
+		} else {
+			t.Errorf("%s assertion was never true: %q\n\tat %s:%d (%s.%s)",
+				p.DisplayType, p.Message, p.Filename, p.Line, p.Classname, p.Funcname)
+		}
+	}
+}
+
+func detailsText(details json.RawMessage) string {
+	if len(details) == 0 {
+		return "null"
+	}
+	return string(details)
+}
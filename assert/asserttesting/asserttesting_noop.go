@@ -0,0 +1,7 @@
+//go:build no_antithesis_sdk
+
+package asserttesting
+
+import "testing"
+
+func Attach(t testing.TB) {}
@@ -0,0 +1,107 @@
+//go:build !no_antithesis_sdk
+
+package asserttesting
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/antithesishq/antithesis-sdk-go/assert"
+)
+
+// fakeT records the failures that Attach reports, instead of failing the real test
+type fakeT struct {
+	testing.TB
+	errors   []string
+	cleanups []func()
+}
+
+func (f *fakeT) Helper() {}
+
+func (f *fakeT) Errorf(format string, args ...any) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+func (f *fakeT) Cleanup(fn func()) {
+	f.cleanups = append(f.cleanups, fn)
+}
+
+func (f *fakeT) finish() {
+	for i := len(f.cleanups) - 1; i >= 0; i-- {
+		f.cleanups[i]()
+	}
+}
+
+func (f *fakeT) failedWith(substr string) bool {
+	for _, e := range f.errors {
+		if strings.Contains(e, substr) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestAlwaysFailureIsReported(t *testing.T) {
+	ft := &fakeT{TB: t}
+	Attach(ft)
+	assert.Always(true, "asserttesting always passes", nil)
+	assert.Always(false, "asserttesting always fails", map[string]any{"key": "value"})
+	ft.finish()
+
+	if !ft.failedWith(`"asserttesting always fails"`) || !ft.failedWith(`"key":"value"`) {
+		t.Fatalf("failure was not reported: %v", ft.errors)
+	}
+	if ft.failedWith(`"asserttesting always passes"`) {
+		t.Fatalf("passing assertion was reported: %v", ft.errors)
+	}
+}
+
+func TestUnreachableFromGoroutineIsReported(t *testing.T) {
+	ft := &fakeT{TB: t}
+	Attach(ft)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		assert.Unreachable("asserttesting unreachable", nil)
+	}()
+	<-done
+	ft.finish()
+
+	if !ft.failedWith(`"asserttesting unreachable"`) {
+		t.Fatalf("Unreachable was not reported: %v", ft.errors)
+	}
+}
+
+func TestEachTestStartsClean(t *testing.T) {
+	for i := 0; i < 2; i++ {
+		ft := &fakeT{TB: t}
+		Attach(ft)
+		assert.AlwaysOrUnreachable(false, "asserttesting repeated failure", nil)
+		ft.finish()
+		if !ft.failedWith(`"asserttesting repeated failure"`) {
+			t.Fatalf("attempt %d: failure was not reported: %v", i, ft.errors)
+		}
+	}
+}
+
+func TestUnsatisfiedSometimesIsReported(t *testing.T) {
+	ft := &fakeT{TB: t}
+	Attach(ft)
+	assert.Sometimes(false, "asserttesting sometimes never true", nil)
+	assert.Sometimes(true, "asserttesting sometimes true", nil)
+	assert.AssertRaw(true, "asserttesting registered reachable", nil,
+		"asserttesting", "TestUnsatisfiedSometimesIsReported", "asserttesting_test.go", 1,
+		false, true, "reachability", "Reachable", "asserttesting registered reachable")
+	ft.finish()
+
+	if !ft.failedWith(`never true: "asserttesting sometimes never true"`) {
+		t.Fatalf("Sometimes was not reported: %v", ft.errors)
+	}
+	if !ft.failedWith(`never reached: "asserttesting registered reachable"`) {
+		t.Fatalf("Reachable was not reported: %v", ft.errors)
+	}
+	if ft.failedWith(`"asserttesting sometimes true"`) {
+		t.Fatalf("satisfied Sometimes was reported: %v", ft.errors)
+	}
+}
@@ -0,0 +1,124 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"context"
+	"testing"
+
+	"github.com/antithesishq/antithesis-sdk-go/internal"
+)
+
+// Repeated evaluations of an assertion which has already been reported
+// should neither lock nor allocate.
+
+func BenchmarkAlwaysRepeated(b *testing.B) {
+	internal.ResetTrackers()
+	details := map[string]any{"key": "value"}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Always(true, "bench always", details)
+	}
+}
+
+func BenchmarkAlwaysRepeatedParallel(b *testing.B) {
+	internal.ResetTrackers()
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			Always(true, "bench always parallel", nil)
+		}
+	})
+}
+
+func BenchmarkSometimesRepeated(b *testing.B) {
+	internal.ResetTrackers()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Sometimes(i%2 == 0, "bench sometimes", nil)
+	}
+}
+
+func BenchmarkAlwaysLazyDetailsRepeated(b *testing.B) {
+	internal.ResetTrackers()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		// LazyDetails allocates its map and closure on every call
+		Always(true, "bench always lazy details", LazyDetails(func() map[string]any {
+			return map[string]any{"i": i}
+		}))
+	}
+}
+
+func BenchmarkAlwaysLazyDetailsShared(b *testing.B) {
+	internal.ResetTrackers()
+	i := 0
+	details := LazyDetails(func() map[string]any {
+		return map[string]any{"i": i}
+	})
+	b.ReportAllocs()
+	for i = 0; i < b.N; i++ {
+		Always(true, "bench always lazy details shared", details)
+	}
+}
+
+func BenchmarkAlwaysLessThanRepeated(b *testing.B) {
+	internal.ResetTrackers()
+	details := map[string]any{"key": "value"}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		AlwaysLessThan(10, 100, "bench always less than", details)
+	}
+}
+
+func BenchmarkAlwaysLessThanFloatRepeated(b *testing.B) {
+	internal.ResetTrackers()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		AlwaysLessThan(1.5, 100.0, "bench always less than float", nil)
+	}
+}
+
+func BenchmarkAlwaysLessThanImproving(b *testing.B) {
+	internal.ResetTrackers()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		// Every evaluation narrows the gap, so guidance is sent each time
+		AlwaysLessThan(i, b.N, "bench always less than improving", nil)
+	}
+}
+
+func TestRepeatedAssertionsDoNotAllocate(t *testing.T) {
+	internal.ResetTrackers()
+	details := map[string]any{"key": "value"}
+	lazy := LazyDetails(func() map[string]any { return details })
+	named_bools := []NamedBool{{"a", true}, {"b", false}}
+	ctx := WithDetails(context.Background(), map[string]any{"request_id": "r1"})
+	for name, f := range map[string]func(){
+		"Always":              func() { Always(true, "alloc always", details) },
+		"AlwaysOrUnreachable": func() { AlwaysOrUnreachable(false, "alloc always or unreachable", details) },
+		"Sometimes":           func() { Sometimes(true, "alloc sometimes", details) },
+		"Reachable":           func() { Reachable("alloc reachable", details) },
+		"Unreachable":         func() { Unreachable("alloc unreachable", details) },
+		"AlwaysLessThan":      func() { AlwaysLessThan(1, 2, "alloc always less than", details) },
+		"LazyDetailsShared":   func() { Always(true, "alloc lazy details shared", lazy) },
+		"AlwaysSome":          func() { AlwaysSome(named_bools, "alloc always some", details) },
+		"SometimesAll":        func() { SometimesAll(named_bools, "alloc sometimes all", details) },
+		"SometimesGreaterThan": func() {
+			SometimesGreaterThanOrEqualTo(2.5, 1.0, "alloc sometimes greater than", details)
+		},
+		"SometimesEach":      func() { SometimesEach("red", "alloc sometimes each", details) },
+		"AlwaysCtx":          func() { AlwaysCtx(ctx, true, "alloc always ctx", details) },
+		"UnreachableCtx":     func() { UnreachableCtx(ctx, "alloc unreachable ctx", details) },
+		"AlwaysLessThanCtx":  func() { AlwaysLessThanCtx(ctx, 1, 2, "alloc always less than ctx", details) },
+		"SometimesAllCtx":    func() { SometimesAllCtx(ctx, named_bools, "alloc sometimes all ctx", details) },
+		"AlwaysEqualCtx":     func() { AlwaysEqualCtx(ctx, 1, 1, "alloc always equal ctx", details) },
+		"AlwaysNoErrorCtx":   func() { AlwaysNoErrorCtx(ctx, nil, "alloc always no error ctx", details) },
+		"AlwaysMonotonicCtx": func() { AlwaysMonotonicCtx(ctx, "key", 1, "alloc always monotonic ctx", details) },
+	} {
+		f() // the first evaluation is emitted
+		if allocs := testing.AllocsPerRun(100, f); allocs != 0 {
+			t.Errorf("%s: %v allocations per repeated call, want 0", name, allocs)
+		}
+	}
+}
@@ -4,61 +4,189 @@ package assert
 
 import (
 	"sync"
+	"sync/atomic"
 
 	"github.com/antithesishq/antithesis-sdk-go/internal"
 )
 
-// TODO: Tracker is intended to prevent sending the same guidance
-// more than once.  In this case, we always send, so the tracker
-// is not presently used.
+// --------------------------------------------------------------------------------
+// booleanGuidance - Tracking Info for Boolean Guidance
+//
+// For GuidanceFnWantAll:
+//   - a combination is better when it has more of the named bools true
+//
+// For GuidanceFnWantNone:
+//   - a combination is better when it has more of the named bools false
+//
+// sent holds the combinations sent so far that are not covered by a better
+// one. A combination is only sent when no combination in sent is at least
+// as good for every named bool. sent is replaced rather than modified, so
+// that it can be consulted without locking.
+//
+// There can be very many combinations none of which is better than another,
+// so once booleanGuidanceLimit combinations are in sent, no more are sent.
+// --------------------------------------------------------------------------------
 type booleanGuidance struct {
-	n int
+	mutex    sync.Mutex // serializes updates to sent
+	sent     atomic.Pointer[[]namedBoolDictionary]
+	maximize bool
 }
 
-type booleanGuidanceTracker map[string]*booleanGuidance
+// booleanGuidanceLimit is the number of combinations kept for each assertion, as for the states of Explore
+var booleanGuidanceLimit = 1 << 16
 
-var (
-	boolean_guidance_tracker       booleanGuidanceTracker = make(booleanGuidanceTracker)
-	boolean_guidance_tracker_mutex sync.Mutex
-	boolean_guidance_info_mutex    sync.Mutex
-)
+type booleanGuidanceTracker struct {
+	entries readMostlyMap[string, *booleanGuidance]
+}
 
-func (tracker booleanGuidanceTracker) getTrackerEntry(messageKey string) *booleanGuidance {
-	var trackerEntry *booleanGuidance
-	var ok bool
+var boolean_guidance_tracker *booleanGuidanceTracker = &booleanGuidanceTracker{}
 
+func (tracker *booleanGuidanceTracker) getTrackerEntry(messageKey string, maximize bool) *booleanGuidance {
 	if tracker == nil {
 		return nil
 	}
 
-	boolean_guidance_tracker_mutex.Lock()
-	defer boolean_guidance_tracker_mutex.Unlock()
-	if trackerEntry, ok = boolean_guidance_tracker[messageKey]; !ok {
-		trackerEntry = newBooleanGuidance()
-		tracker[messageKey] = trackerEntry
+	if trackerEntry, ok := tracker.entries.load(messageKey); ok {
+		return trackerEntry
 	}
+	return tracker.entries.loadOrCreate(messageKey, func() *booleanGuidance {
+		return newBooleanGuidance(maximize)
+	})
+}
 
-	return trackerEntry
+// reset forgets the guidance sent so far
+func (tracker *booleanGuidanceTracker) reset() {
+	tracker.entries.rangeLocked(func(string, *booleanGuidance) bool {
+		return false
+	})
 }
 
 // Create a boolean guidance tracker
-func newBooleanGuidance() *booleanGuidance {
-	trackerInfo := booleanGuidance{}
+func newBooleanGuidance(maximize bool) *booleanGuidance {
+	trackerInfo := booleanGuidance{maximize: maximize}
 	return &trackerInfo
 }
 
+// covers reports whether prev is at least as good as next for every named bool.
+// Combinations of differently named bools never cover each other.
+func (tI *booleanGuidance) covers(prev, next namedBoolDictionary) bool {
+	if len(prev) != len(next) {
+		return false
+	}
+	for name, value := range next {
+		prev_value, ok := prev[name]
+		if !ok {
+			return false
+		}
+		if value != prev_value && value == tI.maximize {
+			return false
+		}
+	}
+	return true
+}
+
+// covers_named_bools is covers for a combination that has not been made into a
+// dictionary yet. It only reports true when named_bools have distinct names.
+func (tI *booleanGuidance) covers_named_bools(prev namedBoolDictionary, named_bools []NamedBool) bool {
+	if len(prev) != len(named_bools) {
+		return false
+	}
+	for _, named_bool := range named_bools {
+		prev_value, ok := prev[named_bool.First]
+		if !ok {
+			return false
+		}
+		if named_bool.Second != prev_value && named_bool.Second == tI.maximize {
+			return false
+		}
+	}
+	return distinct_names(named_bools)
+}
+
+func distinct_names(named_bools []NamedBool) bool {
+	for i := range named_bools {
+		for j := 0; j < i; j++ {
+			if named_bools[i].First == named_bools[j].First {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// covered reports whether a combination sent so far covers named_bools, or
+// no more combinations are sent, without locking or allocating
+func (tI *booleanGuidance) covered(named_bools []NamedBool) bool {
+	sent := tI.sent.Load()
+	if sent == nil {
+		return false
+	}
+	if len(*sent) >= booleanGuidanceLimit {
+		return true
+	}
+	for _, prev := range *sent {
+		if tI.covers_named_bools(prev, named_bools) {
+			return true
+		}
+	}
+	return false
+}
+
+// improves reports whether no combination sent so far covers named_bools,
+// and if so, records it as sent
+func (tI *booleanGuidance) improves(named_bools namedBoolDictionary) bool {
+	tI.mutex.Lock()
+	defer tI.mutex.Unlock()
+
+	var sent []namedBoolDictionary
+	if current := tI.sent.Load(); current != nil {
+		sent = *current
+	}
+	if len(sent) >= booleanGuidanceLimit {
+		return false
+	}
+	stale := 0
+	for _, prev := range sent {
+		if tI.covers(prev, named_bools) {
+			return false
+		}
+		if tI.covers(named_bools, prev) {
+			stale++
+		}
+	}
+
+	// Readers only see the combinations up to the length of the slice they loaded,
+	// so one can be appended in place. Forgetting the combinations that are now
+	// covered needs a new slice.
+	kept := sent
+	if stale > 0 {
+		kept = make([]namedBoolDictionary, 0, len(sent)-stale+1)
+		for _, prev := range sent {
+			if !tI.covers(named_bools, prev) {
+				kept = append(kept, prev)
+			}
+		}
+	}
+	kept = append(kept, named_bools)
+	tI.sent.Store(&kept)
+	return true
+}
+
 func (tI *booleanGuidance) send_value(bgI *booleanGuidanceInfo) {
 	if tI == nil {
 		return
 	}
 
-	boolean_guidance_info_mutex.Lock()
-	defer boolean_guidance_info_mutex.Unlock()
-
-	// The tracker entry should be consulted to determine
-	// if this Guidance info has already been sent, or not.
+	// if this is a catalog entry (bgI.hit is false)
+	// do not record it in the tracker (tI *booleanGuidance)
+	if !bgI.Hit {
+		emitBooleanGuidance(bgI)
+		return
+	}
 
-	emitBooleanGuidance(bgI)
+	if named_bools, ok := bgI.Data.(namedBoolDictionary); ok && tI.improves(named_bools) {
+		emitBooleanGuidance(bgI)
+	}
 }
 
 func emitBooleanGuidance(bgI *booleanGuidanceInfo) error {
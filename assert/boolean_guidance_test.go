@@ -0,0 +1,158 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"testing"
+)
+
+func namedBools(a, b bool) []NamedBool {
+	return []NamedBool{{"a", a}, {"b", b}}
+}
+
+// sentBools returns the combinations sent as guidance, as strings such as "TF"
+func sentBools(t *testing.T, out *capturedOutput) []string {
+	t.Helper()
+	var sent []string
+	for _, g := range out.guidance {
+		data, ok := g.Data.(map[string]any)
+		if !ok {
+			t.Fatalf("unexpected guidance data %v", g.Data)
+		}
+		combination := ""
+		for _, name := range []string{"a", "b", "c"} {
+			if value, ok := data[name]; ok {
+				if value == true {
+					combination += "T"
+				} else {
+					combination += "F"
+				}
+			}
+		}
+		sent = append(sent, combination)
+	}
+	return sent
+}
+
+func checkSent(t *testing.T, sent, want []string) {
+	t.Helper()
+	if len(sent) != len(want) {
+		t.Fatalf("sent guidance %v, want %v", sent, want)
+	}
+	for i := range want {
+		if sent[i] != want[i] {
+			t.Fatalf("sent guidance %v, want %v", sent, want)
+		}
+	}
+}
+
+func TestSometimesAllGuidance(t *testing.T) {
+	out := captureOutput(t)
+
+	// Guidance is only sent when no combination sent so far has all
+	// of the same bools true
+	SometimesAll(namedBools(false, false), "sometimes all", nil)
+	SometimesAll(namedBools(false, false), "sometimes all", nil)
+	SometimesAll(namedBools(true, false), "sometimes all", nil)
+	SometimesAll(namedBools(false, true), "sometimes all", nil)
+	SometimesAll(namedBools(true, false), "sometimes all", nil)
+	SometimesAll(namedBools(false, false), "sometimes all", nil)
+	SometimesAll(namedBools(true, true), "sometimes all", nil)
+	SometimesAll(namedBools(false, true), "sometimes all", nil)
+
+	checkSent(t, sentBools(t, out), []string{"FF", "TF", "FT", "TT"})
+	for _, g := range out.guidance {
+		if !g.Maximize || !g.Hit {
+			t.Errorf("unexpected guidance %+v", g)
+		}
+	}
+}
+
+func TestAlwaysSomeGuidance(t *testing.T) {
+	out := captureOutput(t)
+
+	// Guidance is only sent when no combination sent so far has all
+	// of the same bools false
+	AlwaysSome(namedBools(true, true), "always some", nil)
+	AlwaysSome(namedBools(true, true), "always some", nil)
+	AlwaysSome(namedBools(false, true), "always some", nil)
+	AlwaysSome(namedBools(true, true), "always some", nil)
+	AlwaysSome(namedBools(true, false), "always some", nil)
+	AlwaysSome(namedBools(false, true), "always some", nil)
+	AlwaysSome(namedBools(false, false), "always some", nil)
+	AlwaysSome(namedBools(true, false), "always some", nil)
+
+	checkSent(t, sentBools(t, out), []string{"TT", "FT", "TF", "FF"})
+	for _, g := range out.guidance {
+		if g.Maximize {
+			t.Errorf("unexpected guidance %+v", g)
+		}
+	}
+}
+
+func TestBooleanGuidanceNames(t *testing.T) {
+	out := captureOutput(t)
+
+	// Combinations of different bools are not comparable
+	SometimesAll(namedBools(true, true), "sometimes all names", nil)
+	SometimesAll([]NamedBool{{"a", false}}, "sometimes all names", nil)
+	SometimesAll([]NamedBool{{"a", true}, {"c", false}}, "sometimes all names", nil)
+	SometimesAll([]NamedBool{{"a", false}, {"c", false}}, "sometimes all names", nil)
+
+	checkSent(t, sentBools(t, out), []string{"TT", "F", "TF"})
+}
+
+func TestBooleanGuidanceDuplicateNames(t *testing.T) {
+	out := captureOutput(t)
+
+	// The last of the bools with the same name is the one that counts
+	SometimesAll(namedBools(true, true), "sometimes all duplicates", nil)
+	SometimesAll([]NamedBool{{"a", true}, {"a", true}}, "sometimes all duplicates", nil)
+	SometimesAll([]NamedBool{{"a", true}, {"a", false}}, "sometimes all duplicates", nil)
+
+	checkSent(t, sentBools(t, out), []string{"TT", "T"})
+}
+
+func TestBooleanGuidanceReset(t *testing.T) {
+	captureOutput(t)
+	SometimesAll(namedBools(true, true), "sometimes all reset", nil)
+
+	// Resetting the trackers forgets the combinations sent so far
+	out := captureOutput(t)
+	SometimesAll(namedBools(true, true), "sometimes all reset", nil)
+
+	checkSent(t, sentBools(t, out), []string{"TT"})
+}
+
+func TestBooleanGuidanceRawCatalog(t *testing.T) {
+	out := captureOutput(t)
+
+	// Catalog entries are always sent, and do not affect later guidance
+	for i := 0; i < 2; i++ {
+		BooleanGuidanceRaw(nil, "raw boolean", "raw boolean", "class", "func", "file.go", 1, "all", false)
+	}
+	BooleanGuidanceRaw(namedBools(true, false), "raw boolean", "raw boolean", "class", "func", "file.go", 1, "all", true)
+	BooleanGuidanceRaw(namedBools(true, false), "raw boolean", "raw boolean", "class", "func", "file.go", 1, "all", true)
+
+	if len(out.guidance) != 3 {
+		t.Fatalf("sent guidance %d times, want 3", len(out.guidance))
+	}
+	if out.guidance[0].Hit || out.guidance[1].Hit || !out.guidance[2].Hit {
+		t.Errorf("unexpected guidance %+v", out.guidance)
+	}
+}
+
+func TestBooleanGuidanceLimit(t *testing.T) {
+	out := captureOutput(t)
+	limit := booleanGuidanceLimit
+	t.Cleanup(func() { booleanGuidanceLimit = limit })
+	booleanGuidanceLimit = 3
+
+	// No combination with one true bool is better than another, so the first three
+	// are all kept, and no more combinations are sent once they are
+	for _, bools := range [][]bool{{true, false, false}, {false, true, false}, {false, false, true}, {true, true, false}, {true, true, true}} {
+		SometimesAll([]NamedBool{{"a", bools[0]}, {"b", bools[1]}, {"c", bools[2]}}, "sometimes all limit", nil)
+	}
+
+	checkSent(t, sentBools(t, out), []string{"TFF", "FTF", "FFT"})
+}
@@ -0,0 +1,250 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"context"
+	"time"
+)
+
+type contextDetailsKey struct{}
+
+// WithDetails returns a copy of ctx which carries details, so that every assertion made with one of the Ctx variants of the assertion functions, such as [AlwaysCtx], includes them. Use it to add the IDs of the request or trace being handled to the details of every assertion made while handling it:
+//
+//	ctx = assert.WithDetails(ctx, map[string]any{"request_id": id})
+//	...
+//	assert.AlwaysCtx(ctx, balance >= 0, "balance is never negative", nil)
+//
+// Details added by nested calls to WithDetails are combined, and take precedence over those added by outer calls. The details parameter of an assertion takes precedence over the details carried by its context. Context details do not affect how assertions are aggregated into test properties, which still depends only on their message.
+//
+// There are no Ctx variants of [AlwaysHappensBefore], which relates the events of two calls, of [RegisterInvariant], whose checks run on another goroutine, or of the functions that report panics and crashes, since no single context applies to what they report.
+//
+// details should not be modified after calling WithDetails.
+func WithDetails(ctx context.Context, details map[string]any) context.Context {
+	if len(details) == 0 {
+		return ctx
+	}
+	combined := details
+	if outer := context_details(ctx); len(outer) != 0 {
+		combined = make(map[string]any, len(outer)+len(details))
+		for k, v := range outer {
+			combined[k] = v
+		}
+		for k, v := range details {
+			combined[k] = v
+		}
+	}
+	return context.WithValue(ctx, contextDetailsKey{}, combined)
+}
+
+func context_details(ctx context.Context) map[string]any {
+	if ctx == nil {
+		return nil
+	}
+	details, _ := ctx.Value(contextDetailsKey{}).(map[string]any)
+	return details
+}
+
+// with_context_details defers merging the details carried by ctx into details until the assertion is emitted.
+// Callers only call it once the assertion may be emitted, since it allocates when ctx carries details.
+func with_context_details(ctx context.Context, details map[string]any) map[string]any {
+	ctx_details := context_details(ctx)
+	if len(ctx_details) == 0 {
+		return details
+	}
+	return map[string]any{lazyDetailsKey: lazyDetails(func() map[string]any {
+		merged := make(map[string]any, len(ctx_details)+len(details))
+		for k, v := range ctx_details {
+			merged[k] = v
+		}
+		for k, v := range resolveDetails(details) {
+			merged[k] = v
+		}
+		return merged
+	})}
+}
+
+// AlwaysCtx is [Always], with the details carried by ctx added to details. See [WithDetails].
+func AlwaysCtx(ctx context.Context, condition bool, message string, details map[string]any) {
+	locationInfo := callerLocation(offsetAPICaller)
+	id := makeKey(message, locationInfo)
+	if assertTracker.mayEmit(id, locationInfo, condition) {
+		assertImpl(condition, message, with_context_details(ctx, details), locationInfo, wasHit, mustBeHit, universalTest, alwaysDisplay, id)
+	}
+}
+
+// AlwaysOrUnreachableCtx is [AlwaysOrUnreachable], with the details carried by ctx added to details. See [WithDetails].
+func AlwaysOrUnreachableCtx(ctx context.Context, condition bool, message string, details map[string]any) {
+	locationInfo := callerLocation(offsetAPICaller)
+	id := makeKey(message, locationInfo)
+	if assertTracker.mayEmit(id, locationInfo, condition) {
+		assertImpl(condition, message, with_context_details(ctx, details), locationInfo, wasHit, optionallyHit, universalTest, alwaysOrUnreachableDisplay, id)
+	}
+}
+
+// SometimesCtx is [Sometimes], with the details carried by ctx added to details. See [WithDetails].
+func SometimesCtx(ctx context.Context, condition bool, message string, details map[string]any) {
+	locationInfo := callerLocation(offsetAPICaller)
+	id := makeKey(message, locationInfo)
+	if assertTracker.mayEmit(id, locationInfo, condition) {
+		assertImpl(condition, message, with_context_details(ctx, details), locationInfo, wasHit, mustBeHit, existentialTest, sometimesDisplay, id)
+	}
+}
+
+// UnreachableCtx is [Unreachable], with the details carried by ctx added to details. See [WithDetails].
+func UnreachableCtx(ctx context.Context, message string, details map[string]any) {
+	locationInfo := callerLocation(offsetAPICaller)
+	id := makeKey(message, locationInfo)
+	if assertTracker.mayEmit(id, locationInfo, false) {
+		assertImpl(false, message, with_context_details(ctx, details), locationInfo, wasHit, optionallyHit, reachabilityTest, unreachableDisplay, id)
+	}
+}
+
+// ReachableCtx is [Reachable], with the details carried by ctx added to details. See [WithDetails].
+func ReachableCtx(ctx context.Context, message string, details map[string]any) {
+	locationInfo := callerLocation(offsetAPICaller)
+	id := makeKey(message, locationInfo)
+	if assertTracker.mayEmit(id, locationInfo, true) {
+		assertImpl(true, message, with_context_details(ctx, details), locationInfo, wasHit, mustBeHit, reachabilityTest, reachableDisplay, id)
+	}
+}
+
+// AlwaysGreaterThanCtx is [AlwaysGreaterThan], with the details carried by ctx added to details. See [WithDetails].
+func AlwaysGreaterThanCtx[T Number](ctx context.Context, left, right T, message string, details map[string]any) {
+	alwaysGreaterThanImpl(ctx, callerLocation(offsetAPICaller), left, right, message, details)
+}
+
+// AlwaysGreaterThanOrEqualToCtx is [AlwaysGreaterThanOrEqualTo], with the details carried by ctx added to details. See [WithDetails].
+func AlwaysGreaterThanOrEqualToCtx[T Number](ctx context.Context, left, right T, message string, details map[string]any) {
+	alwaysGreaterThanOrEqualToImpl(ctx, callerLocation(offsetAPICaller), left, right, message, details)
+}
+
+// SometimesGreaterThanCtx is [SometimesGreaterThan], with the details carried by ctx added to details. See [WithDetails].
+func SometimesGreaterThanCtx[T Number](ctx context.Context, left, right T, message string, details map[string]any) {
+	sometimesGreaterThanImpl(ctx, callerLocation(offsetAPICaller), left, right, message, details)
+}
+
+// SometimesGreaterThanOrEqualToCtx is [SometimesGreaterThanOrEqualTo], with the details carried by ctx added to details. See [WithDetails].
+func SometimesGreaterThanOrEqualToCtx[T Number](ctx context.Context, left, right T, message string, details map[string]any) {
+	sometimesGreaterThanOrEqualToImpl(ctx, callerLocation(offsetAPICaller), left, right, message, details)
+}
+
+// AlwaysLessThanCtx is [AlwaysLessThan], with the details carried by ctx added to details. See [WithDetails].
+func AlwaysLessThanCtx[T Number](ctx context.Context, left, right T, message string, details map[string]any) {
+	alwaysLessThanImpl(ctx, callerLocation(offsetAPICaller), left, right, message, details)
+}
+
+// AlwaysLessThanOrEqualToCtx is [AlwaysLessThanOrEqualTo], with the details carried by ctx added to details. See [WithDetails].
+func AlwaysLessThanOrEqualToCtx[T Number](ctx context.Context, left, right T, message string, details map[string]any) {
+	alwaysLessThanOrEqualToImpl(ctx, callerLocation(offsetAPICaller), left, right, message, details)
+}
+
+// SometimesLessThanCtx is [SometimesLessThan], with the details carried by ctx added to details. See [WithDetails].
+func SometimesLessThanCtx[T Number](ctx context.Context, left, right T, message string, details map[string]any) {
+	sometimesLessThanImpl(ctx, callerLocation(offsetAPICaller), left, right, message, details)
+}
+
+// SometimesLessThanOrEqualToCtx is [SometimesLessThanOrEqualTo], with the details carried by ctx added to details. See [WithDetails].
+func SometimesLessThanOrEqualToCtx[T Number](ctx context.Context, left, right T, message string, details map[string]any) {
+	sometimesLessThanOrEqualToImpl(ctx, callerLocation(offsetAPICaller), left, right, message, details)
+}
+
+// AlwaysInRangeCtx is [AlwaysInRange], with the details carried by ctx added to details. See [WithDetails].
+func AlwaysInRangeCtx[T Number](ctx context.Context, value, lo, hi T, message string, details map[string]any) {
+	alwaysInRangeImpl(ctx, callerLocation(offsetAPICaller), value, lo, hi, message, details)
+}
+
+// SometimesInRangeCtx is [SometimesInRange], with the details carried by ctx added to details. See [WithDetails].
+func SometimesInRangeCtx[T Number](ctx context.Context, value, lo, hi T, message string, details map[string]any) {
+	sometimesInRangeImpl(ctx, callerLocation(offsetAPICaller), value, lo, hi, message, details)
+}
+
+// AlwaysSomeCtx is [AlwaysSome], with the details carried by ctx added to details. See [WithDetails].
+func AlwaysSomeCtx(ctx context.Context, named_bools []NamedBool, message string, details map[string]any) {
+	alwaysSomeImpl(ctx, callerLocation(offsetAPICaller), named_bools, message, details)
+}
+
+// SometimesAllCtx is [SometimesAll], with the details carried by ctx added to details. See [WithDetails].
+func SometimesAllCtx(ctx context.Context, named_bools []NamedBool, message string, details map[string]any) {
+	sometimesAllImpl(ctx, callerLocation(offsetAPICaller), named_bools, message, details)
+}
+
+// AlwaysEqualCtx is [AlwaysEqual], with the details carried by ctx added to details. See [WithDetails].
+func AlwaysEqualCtx[T comparable](ctx context.Context, expected, actual T, message string, details map[string]any) {
+	alwaysEqualImpl(ctx, callerLocation(offsetAPICaller), expected, actual, message, details)
+}
+
+// SometimesEqualCtx is [SometimesEqual], with the details carried by ctx added to details. See [WithDetails].
+func SometimesEqualCtx[T comparable](ctx context.Context, expected, actual T, message string, details map[string]any) {
+	sometimesEqualImpl(ctx, callerLocation(offsetAPICaller), expected, actual, message, details)
+}
+
+// AlwaysDeepEqualCtx is [AlwaysDeepEqual], with the details carried by ctx added to details. See [WithDetails].
+func AlwaysDeepEqualCtx[T any](ctx context.Context, expected, actual T, message string, details map[string]any) {
+	alwaysDeepEqualImpl(ctx, callerLocation(offsetAPICaller), expected, actual, message, details)
+}
+
+// SometimesDeepEqualCtx is [SometimesDeepEqual], with the details carried by ctx added to details. See [WithDetails].
+func SometimesDeepEqualCtx[T any](ctx context.Context, expected, actual T, message string, details map[string]any) {
+	sometimesDeepEqualImpl(ctx, callerLocation(offsetAPICaller), expected, actual, message, details)
+}
+
+// AlwaysNoErrorCtx is [AlwaysNoError], with the details carried by ctx added to details. See [WithDetails].
+func AlwaysNoErrorCtx(ctx context.Context, err error, message string, details map[string]any) {
+	alwaysNoErrorImpl(ctx, callerLocation(offsetAPICaller), err, message, details)
+}
+
+// SometimesErrorCtx is [SometimesError], with the details carried by ctx added to details. See [WithDetails].
+func SometimesErrorCtx(ctx context.Context, err error, message string, details map[string]any) {
+	sometimesErrorImpl(ctx, callerLocation(offsetAPICaller), err, message, details)
+}
+
+// SometimesErrorIsCtx is [SometimesErrorIs], with the details carried by ctx added to details. See [WithDetails].
+func SometimesErrorIsCtx(ctx context.Context, err, target error, message string, details map[string]any) {
+	sometimesErrorIsImpl(ctx, callerLocation(offsetAPICaller), err, target, message, details)
+}
+
+// AlwaysErrorAsCtx is [AlwaysErrorAs], with the details carried by ctx added to details. See [WithDetails].
+func AlwaysErrorAsCtx(ctx context.Context, err error, target any, message string, details map[string]any) {
+	alwaysErrorAsImpl(ctx, callerLocation(offsetAPICaller), err, target, message, details)
+}
+
+// SometimesEachCtx is [SometimesEach], with the details carried by ctx added to details. See [WithDetails].
+func SometimesEachCtx(ctx context.Context, value any, message string, details map[string]any) {
+	sometimesEachImpl(ctx, callerLocation(offsetAPICaller), value, message, details)
+}
+
+// SometimesEachOfCtx is [SometimesEachOf], with the details carried by ctx added to details. See [WithDetails].
+func SometimesEachOfCtx[T any](ctx context.Context, value T, expected []T, message string, details map[string]any) {
+	sometimesEachOfImpl(ctx, callerLocation(offsetAPICaller), value, expected, message, details)
+}
+
+// AlwaysMonotonicCtx is [AlwaysMonotonic], with the details carried by ctx added to details. See [WithDetails].
+func AlwaysMonotonicCtx[T Number](ctx context.Context, key string, value T, message string, details map[string]any) {
+	monotonicImpl(ctx, callerLocation(offsetAPICaller), key, value, false, message, details)
+}
+
+// AlwaysStrictlyMonotonicCtx is [AlwaysStrictlyMonotonic], with the details carried by ctx added to details. See [WithDetails].
+func AlwaysStrictlyMonotonicCtx[T Number](ctx context.Context, key string, value T, message string, details map[string]any) {
+	monotonicImpl(ctx, callerLocation(offsetAPICaller), key, value, true, message, details)
+}
+
+// AlwaysUniqueCtx is [AlwaysUnique], with the details carried by ctx added to details. See [WithDetails].
+func AlwaysUniqueCtx[T comparable](ctx context.Context, namespace string, id T, message string, details map[string]any) {
+	alwaysUniqueImpl(ctx, callerLocation(offsetAPICaller), namespace, id, message, details)
+}
+
+// SometimesAtLeastCtx is [SometimesAtLeast], with the details carried by ctx added to details. The assertion is judged over many calls, and is reported with the details of the call that changed its outcome. See [WithDetails].
+func SometimesAtLeastCtx(ctx context.Context, condition bool, n int, message string, details map[string]any) {
+	sometimesAtLeastImpl(ctx, callerLocation(offsetAPICaller), condition, n, message, details)
+}
+
+// AlwaysFractionBelowCtx is [AlwaysFractionBelow], with the details carried by ctx added to details. The assertion is judged over many calls, and is reported with the details of the call that changed its outcome. See [WithDetails].
+func AlwaysFractionBelowCtx(ctx context.Context, condition bool, ratio float64, message string, details map[string]any) {
+	alwaysFractionBelowImpl(ctx, callerLocation(offsetAPICaller), condition, ratio, message, details)
+}
+
+// EventuallyCtx is [Eventually], with the details carried by ctx added to details. Only the details are taken from ctx: the wait is not cancelled with ctx, since it is judged on another goroutine, after the call returns. See [WithDetails].
+func EventuallyCtx(ctx context.Context, message string, timeout time.Duration, predicate func() bool, details map[string]any) {
+	eventuallyImpl(ctx, callerLocation(offsetAPICaller), message, timeout, predicate, details)
+}
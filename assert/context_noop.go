@@ -0,0 +1,72 @@
+//go:build no_antithesis_sdk
+
+package assert
+
+import (
+	"context"
+	"time"
+)
+
+func WithDetails(ctx context.Context, details map[string]any) context.Context { return ctx }
+
+func AlwaysCtx(ctx context.Context, condition bool, message string, details map[string]any) {}
+func AlwaysOrUnreachableCtx(ctx context.Context, condition bool, message string, details map[string]any) {
+}
+func SometimesCtx(ctx context.Context, condition bool, message string, details map[string]any) {}
+func UnreachableCtx(ctx context.Context, message string, details map[string]any)               {}
+func ReachableCtx(ctx context.Context, message string, details map[string]any)                 {}
+
+func AlwaysGreaterThanCtx[T Number](ctx context.Context, left, right T, message string, details map[string]any) {
+}
+func AlwaysGreaterThanOrEqualToCtx[T Number](ctx context.Context, left, right T, message string, details map[string]any) {
+}
+func SometimesGreaterThanCtx[T Number](ctx context.Context, left, right T, message string, details map[string]any) {
+}
+func SometimesGreaterThanOrEqualToCtx[T Number](ctx context.Context, left, right T, message string, details map[string]any) {
+}
+func AlwaysLessThanCtx[T Number](ctx context.Context, left, right T, message string, details map[string]any) {
+}
+func AlwaysLessThanOrEqualToCtx[T Number](ctx context.Context, left, right T, message string, details map[string]any) {
+}
+func SometimesLessThanCtx[T Number](ctx context.Context, left, right T, message string, details map[string]any) {
+}
+func SometimesLessThanOrEqualToCtx[T Number](ctx context.Context, left, right T, message string, details map[string]any) {
+}
+func AlwaysInRangeCtx[T Number](ctx context.Context, value, lo, hi T, message string, details map[string]any) {
+}
+func SometimesInRangeCtx[T Number](ctx context.Context, value, lo, hi T, message string, details map[string]any) {
+}
+func AlwaysSomeCtx(ctx context.Context, named_bools []NamedBool, message string, details map[string]any) {
+}
+func SometimesAllCtx(ctx context.Context, named_bools []NamedBool, message string, details map[string]any) {
+}
+func AlwaysEqualCtx[T comparable](ctx context.Context, expected, actual T, message string, details map[string]any) {
+}
+func SometimesEqualCtx[T comparable](ctx context.Context, expected, actual T, message string, details map[string]any) {
+}
+func AlwaysDeepEqualCtx[T any](ctx context.Context, expected, actual T, message string, details map[string]any) {
+}
+func SometimesDeepEqualCtx[T any](ctx context.Context, expected, actual T, message string, details map[string]any) {
+}
+
+func AlwaysNoErrorCtx(ctx context.Context, err error, message string, details map[string]any)  {}
+func SometimesErrorCtx(ctx context.Context, err error, message string, details map[string]any) {}
+func SometimesErrorIsCtx(ctx context.Context, err, target error, message string, details map[string]any) {
+}
+func AlwaysErrorAsCtx(ctx context.Context, err error, target any, message string, details map[string]any) {
+}
+func SometimesEachCtx(ctx context.Context, value any, message string, details map[string]any) {}
+func SometimesEachOfCtx[T any](ctx context.Context, value T, expected []T, message string, details map[string]any) {
+}
+func AlwaysMonotonicCtx[T Number](ctx context.Context, key string, value T, message string, details map[string]any) {
+}
+func AlwaysStrictlyMonotonicCtx[T Number](ctx context.Context, key string, value T, message string, details map[string]any) {
+}
+func AlwaysUniqueCtx[T comparable](ctx context.Context, namespace string, id T, message string, details map[string]any) {
+}
+func SometimesAtLeastCtx(ctx context.Context, condition bool, n int, message string, details map[string]any) {
+}
+func AlwaysFractionBelowCtx(ctx context.Context, condition bool, ratio float64, message string, details map[string]any) {
+}
+func EventuallyCtx(ctx context.Context, message string, timeout time.Duration, predicate func() bool, details map[string]any) {
+}
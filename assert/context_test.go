@@ -0,0 +1,153 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestWithDetails(t *testing.T) {
+	out := captureOutput(t)
+
+	ctx := WithDetails(context.Background(), map[string]any{"request_id": "r1", "trace_id": "t1"})
+	ctx = WithDetails(ctx, map[string]any{"trace_id": "t2", "span_id": "s1"})
+	AlwaysCtx(ctx, false, "context details", map[string]any{"span_id": "s2"})
+
+	if len(out.assertions) != 1 {
+		t.Fatalf("emitted %d assertions, want 1", len(out.assertions))
+	}
+	got := out.assertions[0]
+	d := got.Details
+	if d["request_id"] != "r1" || d["trace_id"] != "t2" || d["span_id"] != "s2" || len(d) != 3 {
+		t.Errorf("unexpected details %v", d)
+	}
+	if got.DisplayType != alwaysDisplay || got.Location.Funcname != "TestWithDetails" || filepath.Base(got.Location.Filename) != "context_test.go" {
+		t.Errorf("unexpected assertion %+v", got)
+	}
+}
+
+func TestContextAggregation(t *testing.T) {
+	out := captureOutput(t)
+
+	// Assertions with the same message form one property, whatever their context
+	first := WithDetails(context.Background(), map[string]any{"request_id": "r1"})
+	second := WithDetails(context.Background(), map[string]any{"request_id": "r2"})
+	SometimesCtx(first, false, "context aggregation", nil)
+	SometimesCtx(second, false, "context aggregation", nil)
+	SometimesCtx(second, true, "context aggregation", nil)
+	Sometimes(true, "context aggregation", nil)
+
+	if s := summaryFor(t, "context aggregation"); s.PassCount != 2 || s.FailCount != 2 {
+		t.Errorf("unexpected summary %+v", s)
+	}
+	if len(out.assertions) != 2 || out.assertions[1].Details["request_id"] != "r2" {
+		t.Errorf("unexpected assertions %+v", out.assertions)
+	}
+}
+
+func TestRichAssertionsCtx(t *testing.T) {
+	out := captureOutput(t)
+
+	ctx := WithDetails(context.Background(), map[string]any{"request_id": "r1"})
+	AlwaysGreaterThanCtx(ctx, 1, 2, "context greater than", LazyDetails(func() map[string]any {
+		return map[string]any{"lazy": true}
+	}))
+	AlwaysSomeCtx(ctx, []NamedBool{{"a", false}}, "context some", nil)
+	AlwaysEqualCtx(context.Background(), 1, 2, "context equal", nil)
+
+	if len(out.assertions) != 3 {
+		t.Fatalf("emitted %d assertions, want 3", len(out.assertions))
+	}
+	if d := out.assertions[0].Details; d["request_id"] != "r1" || d["lazy"] != true || d["left"] != float64(1) {
+		t.Errorf("unexpected details %v", d)
+	}
+	if d := out.assertions[1].Details; d["request_id"] != "r1" || d["a"] != false {
+		t.Errorf("unexpected details %v", d)
+	}
+	if d := out.assertions[2].Details; d["request_id"] != nil {
+		t.Errorf("unexpected details %v", d)
+	}
+	if len(out.guidance) != 2 {
+		t.Errorf("sent guidance %d times, want 2", len(out.guidance))
+	}
+}
+
+func TestMoreAssertionsCtx(t *testing.T) {
+	out := captureOutput(t)
+
+	ctx := WithDetails(context.Background(), map[string]any{"request_id": "r1"})
+	AlwaysNoErrorCtx(ctx, errors.New("boom"), "context no error", nil)
+	SometimesEachCtx(ctx, "red", "context each", nil)
+	AlwaysMonotonicCtx(ctx, "k", 2, "context monotonic", nil)
+	AlwaysMonotonicCtx(ctx, "k", 1, "context monotonic", nil)
+	AlwaysUniqueCtx(ctx, "context ids", 7, "context unique", nil)
+	AlwaysUniqueCtx(WithDetails(ctx, map[string]any{"request_id": "r2"}), "context ids", 7, "context unique", nil)
+
+	if len(out.assertions) != 6 {
+		t.Fatalf("emitted %d assertions, want 6", len(out.assertions))
+	}
+	for _, a := range out.assertions[:5] {
+		if a.Details["request_id"] != "r1" {
+			t.Errorf("%q has unexpected details %v", a.Message, a.Details)
+		}
+	}
+	d := out.assertions[5].Details
+	first, _ := d["first"].(map[string]any)
+	second, _ := d["second"].(map[string]any)
+	if d["request_id"] != "r2" || first == nil || second == nil {
+		t.Fatalf("unexpected details %v", d)
+	}
+}
+
+// countingContext counts the lookups of the details it carries
+type countingContext struct {
+	context.Context
+	lookups int
+}
+
+func (c *countingContext) Value(key any) any {
+	if _, ok := key.(contextDetailsKey); ok {
+		c.lookups++
+	}
+	return c.Context.Value(key)
+}
+
+func TestContextDetailsOnlyResolvedWhenEmitted(t *testing.T) {
+	captureOutput(t)
+
+	ctx := &countingContext{Context: WithDetails(context.Background(), map[string]any{"request_id": "r1"})}
+	for i := 0; i < 10; i++ {
+		AlwaysCtx(ctx, true, "context resolved always", nil)
+		AlwaysLessThanCtx(ctx, 1, 2, "context resolved less than", nil)
+		AlwaysNoErrorCtx(ctx, nil, "context resolved no error", nil)
+		SometimesEachCtx(ctx, "red", "context resolved each", nil)
+		AlwaysMonotonicCtx(ctx, "k", i, "context resolved monotonic", nil)
+	}
+
+	// Only the first evaluation of each assertion is emitted
+	if ctx.lookups != 5 {
+		t.Errorf("context details looked up %d times, want 5", ctx.lookups)
+	}
+}
+
+func TestThresholdAssertionsCtx(t *testing.T) {
+	out := captureOutput(t)
+
+	ctx := WithDetails(context.Background(), map[string]any{"request_id": "r1"})
+	SometimesAtLeastCtx(ctx, false, 1, "context at least", nil)
+	SometimesAtLeastCtx(WithDetails(ctx, map[string]any{"request_id": "r2"}), true, 1, "context at least", nil)
+	AlwaysFractionBelowCtx(ctx, true, 0.5, "context fraction", nil)
+
+	if len(out.assertions) != 3 {
+		t.Fatalf("emitted %d assertions, want 3", len(out.assertions))
+	}
+	// Each outcome is reported with the details of the call that reached it
+	for i, want := range []string{"r1", "r2", "r1"} {
+		if d := out.assertions[i].Details; d["request_id"] != want {
+			t.Errorf("%q has unexpected details %v", out.assertions[i].Message, d)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"regexp"
+	"runtime"
+	"runtime/debug"
+	"syscall"
+
+	"github.com/antithesishq/antithesis-sdk-go/internal"
+)
+
+const crashMessage = "process crashed"
+
+// EnableCrashReports reports the crashes of this process, from an unrecovered panic or a fatal runtime error, as Unreachable("process crashed", details). The crash output printed by the Go runtime is added to the details parameter, with key crash.
+//
+// A crashing process cannot run any more code, so EnableCrashReports starts a copy of the program to monitor it, with the same arguments and an additional environment variable. Crash output is sent to the monitor with [debug.SetCrashOutput], and when this process exits after writing some, the monitor emits the assertion and exits. The monitor ignores SIGINT and SIGTERM, so that it outlives this process when both are signalled together.
+//
+// Since the monitor is another run of the same program, every package initializer, and main up to the call to EnableCrashReports, runs twice: once in this process, and once in the monitor, where EnableCrashReports does not return. The monitor emits nothing but the crash report, so the assertion catalog, coverage and the SDK version are only registered by this process, but other side effects happen twice. Call it early in main, before anything with side effects, such as opening files or listening on ports. The monitor emits the assertion to the handler set with sdk.SetHandler before the call, if any, so set the handler that should receive crash reports first. The patterns set with [RedactKeys] and [RedactValues] are sent to the monitor whenever they change, so the crash report is redacted as the details of this process would be, wherever they were set.
+//
+// Only crashes that the Go runtime writes crash output for are reported. A process killed by a signal that the runtime does not handle, such as SIGKILL, or that exits with os.Exit, is not reported as crashed. Output that a handler buffers is not flushed when the process crashes. An error is returned if the monitor cannot be started.
+func EnableCrashReports() error {
+	loc := callerLocation(offsetAPICaller)
+	if _, is_monitor := os.LookupEnv(internal.CrashMonitorEnvVar); is_monitor {
+		signal.Ignore(os.Interrupt, syscall.SIGTERM)
+		monitorCrashes(os.Stdin, os.NewFile(3, "redaction"), loc)
+		os.Exit(0)
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	r, w, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	monitor := exec.Command(exe, os.Args[1:]...)
+	monitor.Env = append(os.Environ(), internal.CrashMonitorEnvVar+"=1")
+	monitor.Stdin = r
+	monitor.Stdout = os.Stdout
+	monitor.Stderr = os.Stderr
+
+	// The redaction patterns are sent to the monitor over a pipe of their own, which
+	// Windows cannot pass to it
+	var redaction_r, redaction_w *os.File
+	if runtime.GOOS != "windows" {
+		if redaction_r, redaction_w, err = os.Pipe(); err != nil {
+			w.Close()
+			return err
+		}
+		defer redaction_r.Close()
+		monitor.ExtraFiles = []*os.File{redaction_r}
+	}
+	if err := monitor.Start(); err != nil {
+		w.Close()
+		if redaction_w != nil {
+			redaction_w.Close()
+		}
+		return err
+	}
+	go monitor.Wait()
+	if redaction_w != nil {
+		redaction_mutex.Lock()
+		redaction_to_monitor = redaction_w
+		send_redaction(current_redaction.Load())
+		redaction_mutex.Unlock()
+	}
+
+	// The runtime keeps its own copy of w
+	defer w.Close()
+	return debug.SetCrashOutput(w, debug.CrashOptions{})
+}
+
+// monitorCrashes reads crash output until the monitored process exits,
+// and reports a crash if there was any
+func monitorCrashes(crashOutput io.Reader, redactions io.Reader, loc *locationInfo) {
+	received := make(chan struct{})
+	go func() {
+		defer close(received)
+		if redactions != nil {
+			receive_redaction(redactions)
+		}
+	}()
+	crash, _ := io.ReadAll(crashOutput)
+	if len(crash) == 0 {
+		return
+	}
+
+	// Both pipes are closed when the monitored process exits, so once the crash output
+	// ends, the last patterns it set are received soon after
+	<-received
+	internal.UnmuteCrashMonitor()
+	id := makeKey(crashMessage, loc)
+	details := map[string]any{"crash": string(crash)}
+	assertImpl(false, crashMessage, details, loc, wasHit, optionallyHit, reachabilityTest, unreachableDisplay, id)
+}
+
+// redaction_to_monitor is the pipe the redaction patterns are sent to the crash monitor
+// over, if there is one. Guarded by redaction_mutex.
+var redaction_to_monitor io.WriteCloser
+
+// redactionConfig is how redaction patterns are sent to the crash monitor
+type redactionConfig struct {
+	Keys   []string `json:"keys"`
+	Values []string `json:"values"`
+}
+
+// send_redaction sends r to the crash monitor, if there is one.
+// Must be called with redaction_mutex held.
+func send_redaction(r *redaction) {
+	if redaction_to_monitor == nil {
+		return
+	}
+	var config redactionConfig
+	if r != nil {
+		config.Keys = r.keys
+		for _, value := range r.values {
+			config.Values = append(config.Values, value.String())
+		}
+	}
+	if err := json.NewEncoder(redaction_to_monitor).Encode(config); err != nil {
+		// The monitor has gone
+		redaction_to_monitor.Close()
+		redaction_to_monitor = nil
+	}
+}
+
+// receive_redaction applies the redaction patterns sent by the monitored process, until it exits
+func receive_redaction(redactions io.Reader) {
+	decoder := json.NewDecoder(redactions)
+	for {
+		var config redactionConfig
+		if decoder.Decode(&config) != nil {
+			return
+		}
+		values := make([]*regexp.Regexp, 0, len(config.Values))
+		for _, value := range config.Values {
+			pattern, err := regexp.Compile(value)
+			if err != nil {
+				// Redact too much, rather than too little
+				pattern = regexp.MustCompile(`(?s).+`)
+			}
+			values = append(values, pattern)
+		}
+		update_redaction(func(r *redaction) {
+			r.keys = config.Keys
+			r.values = values
+		})
+	}
+}
@@ -0,0 +1,104 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMonitorCrashes(t *testing.T) {
+	out := captureOutput(t)
+
+	monitorCrashes(strings.NewReader(""), nil, callerLocation(offsetHere))
+	if len(out.assertions) != 0 {
+		t.Fatalf("reported a crash for a normal exit: %+v", out.assertions)
+	}
+
+	monitorCrashes(strings.NewReader("panic: boom\n\ngoroutine 1 [running]:\n"), nil, callerLocation(offsetHere))
+	if len(out.assertions) != 1 {
+		t.Fatalf("emitted %d assertions, want 1", len(out.assertions))
+	}
+	a := out.assertions[0]
+	if a.Message != crashMessage || a.DisplayType != unreachableDisplay || !strings.HasPrefix(a.Details["crash"].(string), "panic: boom") {
+		t.Errorf("unexpected assertion %+v", a)
+	}
+}
+
+const crashHelperEnvVar = "ASSERT_TEST_CRASH_HELPER"
+
+// TestCrashHelper crashes when run by TestEnableCrashReports
+func TestCrashHelper(t *testing.T) {
+	if os.Getenv(crashHelperEnvVar) == "" {
+		t.Skip("only run by TestEnableCrashReports")
+	}
+	if err := EnableCrashReports(); err != nil {
+		t.Fatal(err)
+	}
+	RedactValues(regexp.MustCompile(`secret-\d+`))
+	go panic("crash helper secret-1234")
+	time.Sleep(time.Minute)
+}
+
+func TestEnableCrashReports(t *testing.T) {
+	if testing.Short() {
+		t.Skip("starts processes")
+	}
+	output := filepath.Join(t.TempDir(), "sdk.jsonl")
+	cmd := exec.Command(os.Args[0], "-test.run=^TestCrashHelper$")
+	cmd.Env = append(os.Environ(), crashHelperEnvVar+"=1", "ANTITHESIS_SDK_LOCAL_OUTPUT="+output)
+	if err := cmd.Run(); err == nil {
+		t.Fatal("crash helper did not crash")
+	}
+
+	// The monitor reports the crash after the helper has exited
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		if crash, ok := readCrashAssertion(t, output); ok {
+			text := crash.Details["crash"].(string)
+			if !strings.Contains(text, "panic: crash helper "+redactedMarker) || strings.Contains(text, "secret-1234") {
+				t.Errorf("unexpected crash details %v", crash.Details)
+			}
+
+			// The monitor only emits the crash report
+			if lines := outputLines(t, output); lines != 1 {
+				t.Errorf("wrote %d lines of output, want 1", lines)
+			}
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("crash was not reported")
+}
+
+func readCrashAssertion(t *testing.T, path string) (assertInfo, bool) {
+	f, err := os.Open(path)
+	if err != nil {
+		return assertInfo{}, false
+	}
+	defer f.Close()
+	lines := bufio.NewScanner(f)
+	lines.Buffer(nil, 1<<20)
+	for lines.Scan() {
+		var wrapped wrappedAssertInfo
+		if json.Unmarshal(lines.Bytes(), &wrapped) == nil && wrapped.A != nil && wrapped.A.Message == crashMessage {
+			return *wrapped.A, true
+		}
+	}
+	return assertInfo{}, false
+}
+
+func outputLines(t *testing.T, path string) int {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.Count(string(data), "\n")
+}
@@ -0,0 +1,173 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// maxDiffLines limits the size of the diffs added to details
+const maxDiffLines = 20
+
+type visitedPair struct {
+	expected, actual uintptr
+	typ              reflect.Type
+}
+
+type differ struct {
+	lines   []string
+	visited map[visitedPair]bool
+	omitted int
+}
+
+// diffValues describes how actual differs from expected, one difference per line.
+// Each line starts with the path to the difference, for example `.Items[2].Name`.
+func diffValues(expected, actual any) string {
+	d := differ{visited: map[visitedPair]bool{}}
+	d.diff("", reflect.ValueOf(expected), reflect.ValueOf(actual))
+	if d.omitted > 0 {
+		d.lines = append(d.lines, fmt.Sprintf("... and %d more differences", d.omitted))
+	}
+	return strings.Join(d.lines, "\n")
+}
+
+func (d *differ) report(path string, format string, args ...any) {
+	if len(d.lines) >= maxDiffLines {
+		d.omitted++
+		return
+	}
+	if path == "" {
+		path = "value"
+	}
+	d.lines = append(d.lines, path+": "+fmt.Sprintf(format, args...))
+}
+
+func (d *differ) diff(path string, expected, actual reflect.Value) {
+	if !expected.IsValid() || !actual.IsValid() {
+		if expected.IsValid() != actual.IsValid() {
+			d.report(path, "expected %s, actual %s", formatValue(expected), formatValue(actual))
+		}
+		return
+	}
+	if expected.Type() != actual.Type() {
+		d.report(path, "expected type %s, actual type %s", expected.Type(), actual.Type())
+		return
+	}
+
+	switch expected.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if expected.IsNil() || actual.IsNil() {
+			if expected.IsNil() != actual.IsNil() {
+				d.report(path, "expected %s, actual %s", formatValue(expected), formatValue(actual))
+			}
+			return
+		}
+		if expected.Kind() == reflect.Pointer {
+			if expected.Pointer() == actual.Pointer() {
+				return
+			}
+			pair := visitedPair{expected.Pointer(), actual.Pointer(), expected.Type()}
+			if d.visited[pair] {
+				return
+			}
+			d.visited[pair] = true
+		}
+		d.diff(path, expected.Elem(), actual.Elem())
+
+	case reflect.Struct:
+		for i := 0; i < expected.NumField(); i++ {
+			d.diff(path+"."+expected.Type().Field(i).Name, expected.Field(i), actual.Field(i))
+		}
+
+	case reflect.Slice, reflect.Array:
+		if expected.Kind() == reflect.Slice && expected.IsNil() != actual.IsNil() {
+			d.report(path, "expected %s, actual %s", formatValue(expected), formatValue(actual))
+			return
+		}
+		common := min(expected.Len(), actual.Len())
+		for i := 0; i < common; i++ {
+			d.diff(fmt.Sprintf("%s[%d]", path, i), expected.Index(i), actual.Index(i))
+		}
+		for i := common; i < expected.Len(); i++ {
+			d.report(fmt.Sprintf("%s[%d]", path, i), "missing, expected %s", formatValue(expected.Index(i)))
+		}
+		for i := common; i < actual.Len(); i++ {
+			d.report(fmt.Sprintf("%s[%d]", path, i), "unexpected %s", formatValue(actual.Index(i)))
+		}
+
+	case reflect.Map:
+		if expected.IsNil() != actual.IsNil() {
+			d.report(path, "expected %s, actual %s", formatValue(expected), formatValue(actual))
+			return
+		}
+		for _, key := range sortedKeys(expected, actual) {
+			keyPath := fmt.Sprintf("%s[%s]", path, formatValue(key))
+			e, a := expected.MapIndex(key), actual.MapIndex(key)
+			switch {
+			case !a.IsValid():
+				d.report(keyPath, "missing, expected %s", formatValue(e))
+			case !e.IsValid():
+				d.report(keyPath, "unexpected %s", formatValue(a))
+			default:
+				d.diff(keyPath, e, a)
+			}
+		}
+
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		if expected.Pointer() != actual.Pointer() {
+			d.report(path, "expected %s, actual %s", formatValue(expected), formatValue(actual))
+		}
+
+	default:
+		if !scalarEqual(expected, actual) {
+			d.report(path, "expected %s, actual %s", formatValue(expected), formatValue(actual))
+		}
+	}
+}
+
+func scalarEqual(expected, actual reflect.Value) bool {
+	switch expected.Kind() {
+	case reflect.Bool:
+		return expected.Bool() == actual.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return expected.Int() == actual.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return expected.Uint() == actual.Uint()
+	case reflect.Float32, reflect.Float64:
+		return expected.Float() == actual.Float()
+	case reflect.Complex64, reflect.Complex128:
+		return expected.Complex() == actual.Complex()
+	case reflect.String:
+		return expected.String() == actual.String()
+	}
+	return formatValue(expected) == formatValue(actual)
+}
+
+// sortedKeys returns the union of the keys of two maps, in a stable order
+func sortedKeys(expected, actual reflect.Value) []reflect.Value {
+	seen := map[string]bool{}
+	var keys []reflect.Value
+	for _, m := range []reflect.Value{expected, actual} {
+		for _, key := range m.MapKeys() {
+			text := formatValue(key)
+			if !seen[text] {
+				seen[text] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return formatValue(keys[i]) < formatValue(keys[j])
+	})
+	return keys
+}
+
+func formatValue(v reflect.Value) string {
+	if !v.IsValid() {
+		return "<nil>"
+	}
+	return fmt.Sprintf("%#v", v)
+}
@@ -0,0 +1,78 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"strings"
+	"testing"
+)
+
+type diffNode struct {
+	Name     string
+	Children []*diffNode
+	Labels   map[string]int
+	parent   *diffNode
+}
+
+func TestDiffValues(t *testing.T) {
+	expected := &diffNode{Name: "root", Labels: map[string]int{"a": 1, "b": 2}}
+	expected.Children = []*diffNode{{Name: "child", parent: expected}}
+	actual := &diffNode{Name: "root", Labels: map[string]int{"a": 1, "c": 3}}
+	actual.Children = []*diffNode{{Name: "kid", parent: actual}, {Name: "extra"}}
+
+	diff := diffValues(expected, actual)
+	for _, want := range []string{
+		`.Children[0].Name: expected "child", actual "kid"`,
+		`.Children[1]: unexpected`,
+		`.Labels["b"]: missing, expected 2`,
+		`.Labels["c"]: unexpected 3`,
+	} {
+		if !strings.Contains(diff, want) {
+			t.Errorf("diff does not contain %q:\n%s", want, diff)
+		}
+	}
+	if strings.Contains(diff, ".Name") && strings.Contains(diff, `"root"`) {
+		t.Errorf("diff reports equal fields:\n%s", diff)
+	}
+
+	if diff := diffValues(expected, expected); diff != "" {
+		t.Errorf("diff of a value with itself is not empty:\n%s", diff)
+	}
+}
+
+func TestDiffValuesIsLimited(t *testing.T) {
+	expected := make([]int, 100)
+	actual := make([]int, 100)
+	for i := range actual {
+		actual[i] = i + 1
+	}
+	lines := strings.Split(diffValues(expected, actual), "\n")
+	if len(lines) != maxDiffLines+1 || !strings.HasPrefix(lines[maxDiffLines], "... and 80 more") {
+		t.Errorf("unexpected diff size %d, last line %q", len(lines), lines[len(lines)-1])
+	}
+}
+
+func TestEqualityAssertions(t *testing.T) {
+	out := captureOutput(t)
+
+	AlwaysEqual(3, 3, "equality always equal", nil)
+	AlwaysEqual("a", "b", "equality always not equal", map[string]any{"key": "value"})
+	AlwaysDeepEqual([]int{1, 2}, []int{1, 3}, "equality deep", nil)
+	SometimesDeepEqual(map[string]int{"x": 1}, map[string]int{"x": 1}, "equality sometimes deep", nil)
+
+	if len(out.assertions) != 4 {
+		t.Fatalf("emitted %d assertions, want 4", len(out.assertions))
+	}
+	if a := out.assertions[0]; !a.Condition || a.Details["diff"] != nil {
+		t.Errorf("unexpected assertion %+v", a)
+	}
+	if a := out.assertions[1]; a.Condition || a.Details["expected"] != "a" || a.Details["actual"] != "b" || a.Details["key"] != "value" {
+		t.Errorf("unexpected assertion %+v", a)
+	}
+	if a := out.assertions[2]; a.Condition || a.Details["diff"] != "[1]: expected 2, actual 3" {
+		t.Errorf("unexpected assertion %+v", a)
+	}
+	if a := out.assertions[3]; !a.Condition || a.DisplayType != sometimesDisplay {
+		t.Errorf("unexpected assertion %+v", a)
+	}
+}
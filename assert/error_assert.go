@@ -0,0 +1,149 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// maxErrorChain limits the number of wrapped errors described in details
+const maxErrorChain = 32
+
+// error_chain_types returns the dynamic type of err and of every error it wraps,
+// in the order they are visited by errors.Is and errors.As
+func error_chain_types(err error) []string {
+	var types []string
+	var walk func(err error)
+	walk = func(err error) {
+		if err == nil || len(types) >= maxErrorChain {
+			return
+		}
+		types = append(types, reflect.TypeOf(err).String())
+		for _, wrapped := range unwrap(err) {
+			walk(wrapped)
+		}
+	}
+	walk(err)
+	return types
+}
+
+// unwrap returns the errors err wraps. Unwrap methods are user code, which may panic,
+// for example when called on a nil pointer, and then err is treated as wrapping nothing.
+func unwrap(err error) (wrapped []error) {
+	defer func() {
+		if recover() != nil {
+			wrapped = nil
+		}
+	}()
+	switch x := err.(type) {
+	case interface{ Unwrap() error }:
+		return []error{x.Unwrap()}
+	case interface{ Unwrap() []error }:
+		return x.Unwrap()
+	}
+	return nil
+}
+
+// error_as_target_problem describes why target cannot be passed to errors.As, which
+// panics when it is not a non-nil pointer to a type that implements error, or to an
+// interface type. It returns an empty string for a valid target.
+func error_as_target_problem(target any) string {
+	if target == nil {
+		return "target is nil"
+	}
+	value := reflect.ValueOf(target)
+	if value.Kind() != reflect.Pointer || value.IsNil() {
+		return fmt.Sprintf("target of type %T is not a non-nil pointer", target)
+	}
+	if elem := value.Type().Elem(); elem.Kind() != reflect.Interface && !elem.Implements(errorType) {
+		return fmt.Sprintf("target of type %T does not point to an interface or to a type that implements error", target)
+	}
+	return ""
+}
+
+// add_error_details defers copying details and describing the error chain until the assertion is emitted
+func add_error_details(details map[string]any, err error, extra map[string]any) map[string]any {
+	return map[string]any{lazyDetailsKey: lazyDetails(func() map[string]any {
+		enhancedDetails := map[string]any{}
+		for k, v := range resolveDetails(details) {
+			enhancedDetails[k] = v
+		}
+		if err == nil {
+			enhancedDetails["error"] = nil
+		} else {
+			enhancedDetails["error"] = fmt.Sprintf("%+v", err)
+			enhancedDetails["error_types"] = error_chain_types(err)
+		}
+		for k, v := range extra {
+			enhancedDetails[k] = v
+		}
+		return enhancedDetails
+	})}
+}
+
+func alwaysNoErrorImpl(ctx context.Context, loc *locationInfo, err error, message string, details map[string]any) {
+	id := makeKey(message, loc)
+	condition := err == nil
+	if assertTracker.mayEmit(id, loc, condition) {
+		all_details := add_error_details(with_context_details(ctx, details), err, nil)
+		assertImpl(condition, message, all_details, loc, wasHit, mustBeHit, universalTest, alwaysDisplay, id)
+	}
+}
+
+func sometimesErrorImpl(ctx context.Context, loc *locationInfo, err error, message string, details map[string]any) {
+	id := makeKey(message, loc)
+	condition := err != nil
+	if assertTracker.mayEmit(id, loc, condition) {
+		all_details := add_error_details(with_context_details(ctx, details), err, nil)
+		assertImpl(condition, message, all_details, loc, wasHit, mustBeHit, existentialTest, sometimesDisplay, id)
+	}
+}
+
+func sometimesErrorIsImpl(ctx context.Context, loc *locationInfo, err, target error, message string, details map[string]any) {
+	id := makeKey(message, loc)
+	condition := errors.Is(err, target)
+	if assertTracker.mayEmit(id, loc, condition) {
+		extra := map[string]any{"target": fmt.Sprintf("%+v", target)}
+		all_details := add_error_details(with_context_details(ctx, details), err, extra)
+		assertImpl(condition, message, all_details, loc, wasHit, mustBeHit, existentialTest, sometimesDisplay, id)
+	}
+}
+
+func alwaysErrorAsImpl(ctx context.Context, loc *locationInfo, err error, target any, message string, details map[string]any) {
+	id := makeKey(message, loc)
+	problem := error_as_target_problem(target)
+	condition := problem == "" && errors.As(err, target)
+	if assertTracker.mayEmit(id, loc, condition) {
+		var extra map[string]any
+		if problem != "" {
+			extra = map[string]any{"target_error": problem}
+		} else {
+			extra = map[string]any{"target_type": reflect.TypeOf(target).Elem().String()}
+		}
+		all_details := add_error_details(with_context_details(ctx, details), err, extra)
+		assertImpl(condition, message, all_details, loc, wasHit, mustBeHit, universalTest, alwaysDisplay, id)
+	}
+}
+
+// Equivalent to asserting Always(err == nil, message, details). When err is not nil, its full %+v description is added to the details parameter with key error, and the type of err and of every error it wraps are added with key error_types.
+func AlwaysNoError(err error, message string, details map[string]any) {
+	alwaysNoErrorImpl(context.Background(), callerLocation(offsetAPICaller), err, message, details)
+}
+
+// Equivalent to asserting Sometimes(err != nil, message, details), to check that an error path is exercised. When err is not nil, its full %+v description is added to the details parameter with key error, and the type of err and of every error it wraps are added with key error_types.
+func SometimesError(err error, message string, details map[string]any) {
+	sometimesErrorImpl(context.Background(), callerLocation(offsetAPICaller), err, message, details)
+}
+
+// Equivalent to asserting Sometimes(errors.Is(err, target), message, details). Information about err is added to the details parameter as for [SometimesError], and target is added with key target.
+func SometimesErrorIs(err, target error, message string, details map[string]any) {
+	sometimesErrorIsImpl(context.Background(), callerLocation(offsetAPICaller), err, target, message, details)
+}
+
+// Equivalent to asserting Always(errors.As(err, target), message, details). As with errors.As, target must be a non-nil pointer to a type that implements error, or to an interface type. Do not rely on target being set, since it is not when the SDK is disabled. Information about err is added to the details parameter as for [AlwaysNoError], and the type target points to is added with key target_type. Unlike errors.As, AlwaysErrorAs does not panic when target is invalid: the assertion fails, with the problem added to the details parameter with key target_error.
+func AlwaysErrorAs(err error, target any, message string, details map[string]any) {
+	alwaysErrorAsImpl(context.Background(), callerLocation(offsetAPICaller), err, target, message, details)
+}
@@ -0,0 +1,94 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"testing"
+)
+
+func TestErrorChainTypes(t *testing.T) {
+	pathErr := &fs.PathError{Op: "open", Path: "x", Err: fs.ErrNotExist}
+	err := fmt.Errorf("loading: %w", errors.Join(pathErr, errors.New("other")))
+
+	got := error_chain_types(err)
+	want := []string{"*fmt.wrapError", "*errors.joinError", "*fs.PathError", "*errors.errorString", "*errors.errorString"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("error_chain_types() = %v, want %v", got, want)
+	}
+}
+
+func TestErrorAssertions(t *testing.T) {
+	out := captureOutput(t)
+
+	pathErr := &fs.PathError{Op: "open", Path: "x", Err: fs.ErrNotExist}
+	wrapped := fmt.Errorf("loading: %w", pathErr)
+
+	AlwaysNoError(nil, "no error", nil)
+	AlwaysNoError(wrapped, "no error", map[string]any{"attempt": 2})
+	SometimesError(nil, "some error", nil)
+	SometimesErrorIs(wrapped, fs.ErrNotExist, "error is", nil)
+	var target *fs.PathError
+	AlwaysErrorAs(errors.New("plain"), &target, "error as", nil)
+
+	if len(out.assertions) != 5 {
+		t.Fatalf("emitted %d assertions, want 5", len(out.assertions))
+	}
+	conditions := []bool{true, false, false, true, false}
+	for i, a := range out.assertions {
+		if a.Condition != conditions[i] {
+			t.Errorf("assertion %q has condition %v, want %v", a.Message, a.Condition, conditions[i])
+		}
+	}
+
+	failure := out.assertions[1].Details
+	if failure["error"] != "loading: open x: file does not exist" || failure["attempt"] != float64(2) {
+		t.Errorf("unexpected details %v", failure)
+	}
+	if fmt.Sprint(failure["error_types"]) != "[*fmt.wrapError *fs.PathError *errors.errorString]" {
+		t.Errorf("unexpected error types %v", failure["error_types"])
+	}
+	if got := out.assertions[3].Details["target"]; got != "file does not exist" {
+		t.Errorf("target = %v", got)
+	}
+	if got := out.assertions[4].Details["target_type"]; got != "*fs.PathError" {
+		t.Errorf("target_type = %v", got)
+	}
+}
+
+// nilWrapper panics when Unwrap is called on a nil pointer
+type nilWrapper struct {
+	err error
+}
+
+func (w *nilWrapper) Error() string {
+	return "nil wrapper"
+}
+
+func (w *nilWrapper) Unwrap() error {
+	return w.err
+}
+
+func TestErrorAssertionsWithBadArguments(t *testing.T) {
+	out := captureOutput(t)
+
+	var wrapper *nilWrapper
+	AlwaysNoError(wrapper, "bad no error", nil)
+	AlwaysErrorAs(errors.New("plain"), nil, "bad error as", nil)
+	var target fs.PathError
+	AlwaysErrorAs(nil, &target, "bad error as type", nil)
+
+	if len(out.assertions) != 3 {
+		t.Fatalf("emitted %d assertions, want 3", len(out.assertions))
+	}
+	if got := out.assertions[0].Details["error_types"]; fmt.Sprint(got) != "[*assert.nilWrapper]" {
+		t.Errorf("error_types = %v", got)
+	}
+	for _, a := range out.assertions[1:] {
+		if a.Condition || a.Details["target_error"] == nil {
+			t.Errorf("unexpected assertion %+v", a)
+		}
+	}
+}
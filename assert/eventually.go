@@ -0,0 +1,129 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/antithesishq/antithesis-sdk-go/internal"
+)
+
+// eventuallyPollInterval is the longest time between two evaluations of an Eventually predicate
+var eventuallyPollInterval = 100 * time.Millisecond
+
+// eventually_poll_interval polls at least ten times before the deadline
+func eventually_poll_interval(timeout time.Duration) time.Duration {
+	interval := min(timeout/10, eventuallyPollInterval)
+	return max(interval, time.Millisecond)
+}
+
+// eventuallyWait is a call to Eventually
+type eventuallyWait struct {
+	message   string
+	timeout   time.Duration
+	predicate func() bool
+	details   map[string]any
+	loc       *locationInfo
+	id        string
+}
+
+// The waits which start once setup has completed, keyed by id. A single goroutine
+// waits for setup to complete on their behalf.
+var (
+	eventually_pending_mutex sync.Mutex
+	eventually_pending       map[string]*eventuallyWait
+)
+
+// Eventually asserts that predicate becomes true within timeout. It returns immediately, and polls predicate on a new goroutine until it returns true or the deadline passes. Use it for liveness properties, such as a cluster electing a leader after a partition heals.
+//
+// The corresponding test property is reported like an Always assertion named "<message>", which passes if predicate returns true in time and fails if the deadline passes first. Information about the wait will automatically be added to the details parameter, with keys timeout, elapsed and polls. If predicate panics, polling stops, and the property fails with information about the panic added to the details, with keys panic, panic_type and stack.
+//
+// The deadline only starts once setup has completed, as reported by lifecycle.SetupComplete. Until then, predicate is not called, and a program which never calls lifecycle.SetupComplete never reports the property. When Eventually is called with the same message again, it starts a new wait, except before setup has completed, when it replaces the wait that has not started yet.
+func Eventually(message string, timeout time.Duration, predicate func() bool, details map[string]any) {
+	eventuallyImpl(context.Background(), callerLocation(offsetAPICaller), message, timeout, predicate, details)
+}
+
+func eventuallyImpl(ctx context.Context, loc *locationInfo, message string, timeout time.Duration, predicate func() bool, details map[string]any) {
+	w := &eventuallyWait{message, timeout, predicate, with_context_details(ctx, details), loc, makeKey(message, loc)}
+
+	select {
+	case <-internal.SetupCompleted():
+		go w.run()
+		return
+	default:
+	}
+
+	eventually_pending_mutex.Lock()
+	defer eventually_pending_mutex.Unlock()
+	if eventually_pending == nil {
+		eventually_pending = map[string]*eventuallyWait{}
+		go start_pending_eventually()
+	}
+	eventually_pending[w.id] = w
+}
+
+// start_pending_eventually starts the waits pending when setup completes
+func start_pending_eventually() {
+	<-internal.SetupCompleted()
+	eventually_pending_mutex.Lock()
+	pending := eventually_pending
+	eventually_pending = nil
+	eventually_pending_mutex.Unlock()
+	for _, w := range pending {
+		go w.run()
+	}
+}
+
+func (w *eventuallyWait) run() {
+	start := time.Now()
+	deadline := time.NewTimer(w.timeout)
+	defer deadline.Stop()
+	poll := time.NewTicker(eventually_poll_interval(w.timeout))
+	defer poll.Stop()
+
+	polls := 0
+	for {
+		polls++
+		held, panicked := w.evaluate()
+		if held || panicked != nil {
+			w.emit(held, time.Since(start), polls, panicked)
+			return
+		}
+		select {
+		case <-poll.C:
+		case <-deadline.C:
+			// A final chance, in case predicate became true while waiting
+			polls++
+			held, panicked = w.evaluate()
+			w.emit(held, time.Since(start), polls, panicked)
+			return
+		}
+	}
+}
+
+// evaluate calls predicate, and describes the panic if it panics
+func (w *eventuallyWait) evaluate() (held bool, panicked map[string]any) {
+	defer func() {
+		if r := recover(); r != nil {
+			held, panicked = false, panic_details(r)
+		}
+	}()
+	return w.predicate(), nil
+}
+
+func (w *eventuallyWait) emit(condition bool, elapsed time.Duration, polls int, panicked map[string]any) {
+	if assertTracker.mayEmit(w.id, w.loc, condition) {
+		extra := map[string]any{
+			"timeout": w.timeout.String(),
+			"elapsed": elapsed.String(),
+			"polls":   polls,
+		}
+		for k, v := range panicked {
+			extra[k] = v
+		}
+		all_details := add_extra_details(w.details, extra)
+		assertImpl(condition, w.message, all_details, w.loc, wasHit, mustBeHit, universalTest, alwaysDisplay, w.id)
+	}
+}
@@ -0,0 +1,111 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"context"
+	"encoding/json"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/antithesishq/antithesis-sdk-go/internal"
+)
+
+// assertionChannel receives the assertions emitted from other goroutines
+type assertionChannel chan assertInfo
+
+func (c assertionChannel) Output(message string) {
+	var wrapped wrappedAssertInfo
+	if json.Unmarshal([]byte(message), &wrapped) == nil && wrapped.A != nil {
+		c <- *wrapped.A
+	}
+}
+
+func receiveAssertion(t *testing.T, c assertionChannel) assertInfo {
+	t.Helper()
+	select {
+	case a := <-c:
+		return a
+	case <-time.After(5 * time.Second):
+		t.Fatal("no assertion emitted")
+		return assertInfo{}
+	}
+}
+
+func TestEventually(t *testing.T) {
+	internal.ResetTrackers()
+	c := make(assertionChannel, 10)
+	internal.SetOutputHandler(c)
+	t.Cleanup(func() { internal.SetOutputHandler(nil) })
+
+	var calls atomic.Int32
+	Eventually("eventually true", time.Second, func() bool {
+		return calls.Add(1) >= 3
+	}, map[string]any{"cluster": "a"})
+	Eventually("eventually false", 50*time.Millisecond, func() bool {
+		return false
+	}, nil)
+
+	select {
+	case <-internal.SetupCompleted():
+	default:
+		time.Sleep(20 * time.Millisecond)
+		if n := calls.Load(); n != 0 {
+			t.Errorf("predicate called %d times before setup completed", n)
+		}
+		internal.MarkSetupComplete()
+	}
+
+	results := map[string]assertInfo{}
+	for i := 0; i < 2; i++ {
+		a := receiveAssertion(t, c)
+		results[a.Message] = a
+	}
+
+	passed := results["eventually true"]
+	if !passed.Condition || passed.DisplayType != alwaysDisplay || passed.Details["polls"] != float64(3) || passed.Details["cluster"] != "a" {
+		t.Errorf("unexpected assertion %+v", passed)
+	}
+	failed := results["eventually false"]
+	if failed.Condition || failed.Details["timeout"] != "50ms" {
+		t.Errorf("unexpected assertion %+v", failed)
+	}
+}
+
+func TestEventuallyPredicatePanics(t *testing.T) {
+	internal.ResetTrackers()
+	c := make(assertionChannel, 10)
+	internal.SetOutputHandler(c)
+	t.Cleanup(func() { internal.SetOutputHandler(nil) })
+	internal.MarkSetupComplete()
+
+	var calls atomic.Int32
+	Eventually("eventually panics", time.Second, func() bool {
+		calls.Add(1)
+		panic("no leader")
+	}, nil)
+
+	a := receiveAssertion(t, c)
+	if a.Message != "eventually panics" || a.Condition || a.Details["panic"] != "no leader" || a.Details["polls"] != float64(1) {
+		t.Errorf("unexpected assertion %+v", a)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("predicate called %d times after panicking, want 1", n)
+	}
+}
+
+func TestEventuallyCtx(t *testing.T) {
+	internal.ResetTrackers()
+	c := make(assertionChannel, 10)
+	internal.SetOutputHandler(c)
+	t.Cleanup(func() { internal.SetOutputHandler(nil) })
+	internal.MarkSetupComplete()
+
+	ctx := WithDetails(context.Background(), map[string]any{"request_id": "r1"})
+	EventuallyCtx(ctx, "context eventually", time.Second, func() bool { return true }, nil)
+
+	if a := receiveAssertion(t, c); a.Message != "context eventually" || a.Details["request_id"] != "r1" {
+		t.Errorf("unexpected assertion %+v", a)
+	}
+}
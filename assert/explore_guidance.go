@@ -0,0 +1,106 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"encoding/json"
+	"hash/maphash"
+	"sync"
+
+	"github.com/antithesishq/antithesis-sdk-go/internal"
+)
+
+// exploreStateLimit is the number of states sent for each Explore call, which bounds
+// the memory taken by their fingerprints to about 1 MiB
+const exploreStateLimit = 1 << 16
+
+// exploreGuidanceInfo remembers the fingerprints of the states sent for an Explore call
+type exploreGuidanceInfo struct {
+	mutex sync.Mutex
+	sent  map[uint64]bool
+}
+
+type exploreGuidanceTracker struct {
+	entries readMostlyMap[string, *exploreGuidanceInfo]
+	seed    maphash.Seed
+}
+
+var explore_guidance_tracker *exploreGuidanceTracker = &exploreGuidanceTracker{seed: maphash.MakeSeed()}
+
+func (tracker *exploreGuidanceTracker) getTrackerEntry(messageKey string) *exploreGuidanceInfo {
+	if tI, ok := tracker.entries.load(messageKey); ok {
+		return tI
+	}
+	return tracker.entries.loadOrCreate(messageKey, func() *exploreGuidanceInfo {
+		return &exploreGuidanceInfo{sent: map[uint64]bool{}}
+	})
+}
+
+// reset forgets the states sent so far
+func (tracker *exploreGuidanceTracker) reset() {
+	tracker.entries.rangeLocked(func(string, *exploreGuidanceInfo) bool {
+		return false
+	})
+}
+
+// first_send reports whether the state with this fingerprint has not been sent before, and records it as sent.
+// Once exploreStateLimit states have been sent, no more are.
+func (tI *exploreGuidanceInfo) first_send(fingerprint uint64) bool {
+	tI.mutex.Lock()
+	defer tI.mutex.Unlock()
+	if tI.sent[fingerprint] || len(tI.sent) >= exploreStateLimit {
+		return false
+	}
+	tI.sent[fingerprint] = true
+	return true
+}
+
+func exploreGuidanceImpl(state any, message, id string, loc *locationInfo, hit bool) {
+	gI := &guidanceInfo{
+		GuidanceType: get_guidance_type_string(guidanceFnExplore),
+		Message:      message,
+		Id:           id,
+		Location:     loc,
+		Maximize:     uses_maximize(guidanceFnExplore),
+		Hit:          hit,
+	}
+	if !hit {
+		emitGuidance(gI)
+		return
+	}
+
+	// States are identified by their JSON encoding
+	encoded := internal.Prepare_json(normalize(state))
+	if encoded.Err() != nil {
+		// Emitting the state reports that it could not be marshaled
+		encoded.Emit()
+		return
+	}
+	data := encoded.Bytes()
+	tI := explore_guidance_tracker.getTrackerEntry(id)
+	if tI.first_send(maphash.Bytes(explore_guidance_tracker.seed, data)) {
+		gI.Data = json.RawMessage(data)
+		emitGuidance(gI)
+	}
+}
+
+// Explore tells Antithesis that the program has reached state, an abstract description of the state of the program or workload, such as the roles of the nodes in a cluster. Antithesis is guided towards reaching states it has not seen before, which may help it find more bugs. Explore is not an assertion, and does not create a test property.
+//
+// Each state is only sent the first time it is reached for a message. States are compared by their JSON encoding, and are remembered for the life of the process, so they should come from a bounded set: describe the state in terms of a few abstract features, rather than including counters or timestamps. At most 65536 different states are sent for each message, and any states beyond those are ignored. A state that cannot be encoded as JSON is reported as a failure to emit output, like any other, and is otherwise ignored.
+func Explore(message string, state any) {
+	loc := callerLocation(offsetAPICaller)
+	id := makeKey(message, loc)
+	exploreGuidanceImpl(state, message, id, loc, wasHit)
+}
+
+// ExploreGuidanceRaw is a low-level method designed to be used by third-party frameworks. Regular users of the assert package should not call it.
+func ExploreGuidanceRaw(
+	state any,
+	message, id string,
+	classname, funcname, filename string,
+	line int,
+	hit bool,
+) {
+	loc := &locationInfo{classname, funcname, filename, line, columnUnknown}
+	exploreGuidanceImpl(state, message, id, loc, hit)
+}
@@ -0,0 +1,63 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"testing"
+)
+
+func TestExplore(t *testing.T) {
+	out := captureOutput(t)
+
+	type roles struct {
+		Followers int `json:"followers"`
+		Leaders   int `json:"leaders"`
+	}
+	Explore("cluster roles", roles{2, 1})
+	Explore("cluster roles", roles{2, 1})
+	Explore("cluster roles", roles{3, 0})
+	// The same state, with the same JSON encoding
+	Explore("cluster roles", map[string]any{"followers": 3, "leaders": 0})
+	Explore("cluster roles", &roles{2, 1})
+
+	if len(out.assertions) != 0 {
+		t.Errorf("Explore emitted assertions %+v", out.assertions)
+	}
+	if len(out.guidance) != 2 {
+		t.Fatalf("sent guidance %d times, want 2", len(out.guidance))
+	}
+	g := out.guidance[1]
+	if g.GuidanceType != "json" || g.Maximize || !g.Hit || g.Message != "cluster roles" {
+		t.Errorf("unexpected guidance %+v", g)
+	}
+	if state := g.Data.(map[string]any); state["leaders"] != float64(0) || state["followers"] != float64(3) {
+		t.Errorf("unexpected state %v", g.Data)
+	}
+}
+
+func TestExploreGuidanceRaw(t *testing.T) {
+	out := captureOutput(t)
+
+	ExploreGuidanceRaw(nil, "raw explore", "raw explore", "main", "main", "main.go", 7, false)
+	ExploreGuidanceRaw(nil, "raw explore", "raw explore", "main", "main", "main.go", 7, false)
+
+	if len(out.guidance) != 2 || out.guidance[0].Hit || out.guidance[0].Data != nil {
+		t.Errorf("unexpected guidance %+v", out.guidance)
+	}
+}
+
+func TestExploreStateLimit(t *testing.T) {
+	explore_guidance_tracker.reset()
+	t.Cleanup(explore_guidance_tracker.reset)
+
+	tI := explore_guidance_tracker.getTrackerEntry("unbounded states")
+	sent := 0
+	for i := 0; i < exploreStateLimit+10; i++ {
+		if tI.first_send(uint64(i)) {
+			sent++
+		}
+	}
+	if sent != exploreStateLimit {
+		t.Errorf("sent %d states, want %d", sent, exploreStateLimit)
+	}
+}
@@ -0,0 +1,130 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"reflect"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/antithesishq/antithesis-sdk-go/internal"
+)
+
+type invariant struct {
+	message string
+	check   func() (bool, map[string]any)
+	loc     *locationInfo
+}
+
+var invariants struct {
+	mutex sync.Mutex
+	list  []*invariant
+}
+
+// invariantChecker controls the goroutine started by SetInvariantInterval
+var invariantChecker struct {
+	mutex sync.Mutex
+	stop  chan struct{} // closed to stop the background checks
+	done  chan struct{} // closed once the background checks have stopped
+}
+
+// RegisterInvariant registers check to be evaluated by [CheckInvariants], and in the background as configured by [SetInvariantInterval]. Each evaluation is reported as Always(condition, message, details), with the condition and details returned by check. Use it for properties of shared state, such as a ledger balancing, instead of asserting them after every change to that state.
+//
+// The test property is registered immediately, so that it is reported as failing if the invariant is never checked. Registering another check with the same message replaces the previous one, and moves it to the end of the order.
+func RegisterInvariant(message string, check func() (bool, map[string]any)) {
+	loc := callerLocation(offsetAPICaller)
+	id := makeKey(message, loc)
+	assertImpl(false, message, nil, loc, !wasHit, mustBeHit, universalTest, alwaysDisplay, id)
+
+	inv := &invariant{message: message, check: check, loc: loc}
+	invariants.mutex.Lock()
+	defer invariants.mutex.Unlock()
+	// The list is copied, since CheckInvariants may be iterating over it
+	list := make([]*invariant, 0, len(invariants.list)+1)
+	for _, existing := range invariants.list {
+		if existing.message != message {
+			list = append(list, existing)
+		}
+	}
+	invariants.list = append(list, inv)
+}
+
+// CheckInvariants evaluates every invariant registered with [RegisterInvariant], in the order they were registered, from the calling goroutine.
+func CheckInvariants() {
+	invariants.mutex.Lock()
+	list := invariants.list
+	invariants.mutex.Unlock()
+
+	for _, inv := range list {
+		condition, details := inv.check()
+		id := makeKey(inv.message, inv.loc)
+		if assertTracker.mayEmit(id, inv.loc, condition) {
+			assertImpl(condition, inv.message, details, inv.loc, wasHit, mustBeHit, universalTest, alwaysDisplay, id)
+		}
+	}
+}
+
+// SetInvariantInterval evaluates the registered invariants every interval, on a background goroutine, as if by calling [CheckInvariants]. The background checks only start once setup has completed, as reported by lifecycle.SetupComplete. An interval of zero or less stops them, waiting for any check in progress to finish. By default, invariants are only evaluated by calling CheckInvariants.
+//
+// A check may call SetInvariantInterval, but when the check runs in the background, the checks it replaces or stops include its own, so the call returns without waiting for them.
+func SetInvariantInterval(interval time.Duration) {
+	invariantChecker.mutex.Lock()
+	stop, done := invariantChecker.stop, invariantChecker.done
+	invariantChecker.stop, invariantChecker.done = nil, nil
+	if stop != nil {
+		close(stop)
+	}
+	if interval > 0 {
+		invariantChecker.stop = make(chan struct{})
+		invariantChecker.done = make(chan struct{})
+		go checkInvariantsEvery(interval, invariantChecker.stop, invariantChecker.done)
+	}
+	invariantChecker.mutex.Unlock()
+
+	// The lock is not held while waiting, since the check in progress may itself call SetInvariantInterval
+	if done != nil && !in_background_check() {
+		<-done
+	}
+}
+
+func checkInvariantsEvery(interval time.Duration, stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+	select {
+	case <-internal.SetupCompleted():
+	case <-stop:
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			CheckInvariants()
+		case <-stop:
+			return
+		}
+	}
+}
+
+var checkInvariantsEveryName = runtime.FuncForPC(reflect.ValueOf(checkInvariantsEvery).Pointer()).Name()
+
+// in_background_check reports whether the calling goroutine is the one running the background checks
+func in_background_check() bool {
+	pcs := make([]uintptr, 64)
+	n := runtime.Callers(2, pcs)
+	for n == len(pcs) {
+		pcs = make([]uintptr, 2*len(pcs))
+		n = runtime.Callers(2, pcs)
+	}
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if frame.Function == checkInvariantsEveryName {
+			return true
+		}
+		if !more {
+			return false
+		}
+	}
+}
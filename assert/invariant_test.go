@@ -0,0 +1,128 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/antithesishq/antithesis-sdk-go/internal"
+)
+
+func TestCheckInvariants(t *testing.T) {
+	out := captureOutput(t)
+
+	balance := 0
+	RegisterInvariant("invariant balanced", func() (bool, map[string]any) {
+		return balance == 0, map[string]any{"balance": balance}
+	})
+	RegisterInvariant("invariant never checked", nil)
+	if s := summaryFor(t, "invariant never checked"); s.Verdict != VerdictNeverHit {
+		t.Errorf("unchecked invariant has verdict %v", s.Verdict)
+	}
+	RegisterInvariant("invariant never checked", func() (bool, map[string]any) { return true, nil })
+
+	CheckInvariants()
+	balance = 5
+	CheckInvariants()
+	CheckInvariants()
+
+	// Invariants registered by other tests are checked too
+	var hits []assertInfo
+	for _, a := range out.assertions {
+		if a.Hit && a.Message != "invariant in background" {
+			hits = append(hits, a)
+		}
+	}
+	if len(hits) != 3 {
+		t.Fatalf("emitted %d hits, want 3", len(hits))
+	}
+	if hits[2].Message != "invariant balanced" || hits[2].Condition || hits[2].Details["balance"] != float64(5) {
+		t.Errorf("unexpected failure %+v", hits[2])
+	}
+	if s := summaryFor(t, "invariant balanced"); s.Verdict != VerdictFailed || s.PassCount != 1 || s.FailCount != 2 {
+		t.Errorf("unexpected summary %+v", s)
+	}
+}
+
+func TestInvariantInterval(t *testing.T) {
+	internal.ResetTrackers()
+	internal.MarkSetupComplete()
+
+	var checks atomic.Int32
+	RegisterInvariant("invariant in background", func() (bool, map[string]any) {
+		checks.Add(1)
+		return true, nil
+	})
+	SetInvariantInterval(time.Millisecond)
+	deadline := time.Now().Add(5 * time.Second)
+	for checks.Load() < 3 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	SetInvariantInterval(0)
+
+	if n := checks.Load(); n < 3 {
+		t.Errorf("invariant checked %d times in the background, want at least 3", n)
+	}
+}
+
+func TestSetInvariantIntervalFromCheck(t *testing.T) {
+	internal.ResetTrackers()
+	internal.MarkSetupComplete()
+	invariants.mutex.Lock()
+	registered := invariants.list
+	invariants.mutex.Unlock()
+	t.Cleanup(func() {
+		SetInvariantInterval(0)
+		invariants.mutex.Lock()
+		invariants.list = registered
+		invariants.mutex.Unlock()
+	})
+
+	// A background check stops the background checks while another goroutine is doing so too
+	var calls atomic.Int32
+	started := make(chan struct{})
+	RegisterInvariant("invariant stops checks", func() (bool, map[string]any) {
+		if calls.Add(1) == 1 && in_background_check() {
+			close(started)
+			time.Sleep(50 * time.Millisecond)
+			SetInvariantInterval(0)
+		}
+		return true, nil
+	})
+	SetInvariantInterval(time.Millisecond)
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("invariant was not checked in the background")
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		SetInvariantInterval(0)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(10 * time.Second):
+		t.Fatal("stopping the background checks deadlocked")
+	}
+
+	// A background check stops the checks that are running it
+	var own_calls atomic.Int32
+	own_stopped := make(chan struct{})
+	RegisterInvariant("invariant stops its own checks", func() (bool, map[string]any) {
+		if in_background_check() && own_calls.Add(1) == 1 {
+			SetInvariantInterval(0)
+			close(own_stopped)
+		}
+		return true, nil
+	})
+	SetInvariantInterval(time.Millisecond)
+	select {
+	case <-own_stopped:
+	case <-time.After(10 * time.Second):
+		t.Fatal("a background check stopping its own checks deadlocked")
+	}
+}
@@ -0,0 +1,55 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+// lazyDetails is stored as a value in a details map, and is replaced
+// by the entries of the map it returns when the assertion is emitted
+type lazyDetails func() map[string]any
+
+const lazyDetailsKey = "antithesis_lazy_details"
+
+// LazyDetails returns a details map whose contents are computed by fn only when the assertion is actually emitted. Antithesis only needs the details of the first passing and the first failing evaluation of each assertion, so fn is not called for the vast majority of evaluations. Use it to include expensive context, such as a dump of internal state, in assertions on hot paths:
+//
+//	assert.Always(ok, "ledger balances", assert.LazyDetails(func() map[string]any {
+//		return map[string]any{"ledger": ledger.Dump()}
+//	}))
+//
+// Entries may be added to the returned map. They are emitted along with the entries returned by fn, and take precedence over them.
+//
+// fn may be called from any goroutine that evaluates the assertion, and should not retain the map it returns.
+//
+// LazyDetails defers the cost of computing the details, not of the call itself: the returned map, and fn when it is a closure, are allocated on every call, even when the assertion is not emitted. Where those small allocations matter, create the details once and pass the same map to every evaluation, with fn reading the current state when it is called.
+func LazyDetails(fn func() map[string]any) map[string]any {
+	return map[string]any{lazyDetailsKey: lazyDetails(fn)}
+}
+
+func hasLazyDetails(details map[string]any) bool {
+	for _, v := range details {
+		if _, ok := v.(lazyDetails); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveDetails evaluates any lazyDetails in details. When there are none,
+// details is returned as is, without being copied.
+func resolveDetails(details map[string]any) map[string]any {
+	if !hasLazyDetails(details) {
+		return details
+	}
+	resolved := map[string]any{}
+	for _, v := range details {
+		if fn, ok := v.(lazyDetails); ok && fn != nil {
+			for lk, lv := range resolveDetails(fn()) {
+				resolved[lk] = lv
+			}
+		}
+	}
+	for k, v := range details {
+		if _, ok := v.(lazyDetails); !ok {
+			resolved[k] = v
+		}
+	}
+	return resolved
+}
@@ -0,0 +1,94 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/antithesishq/antithesis-sdk-go/internal"
+)
+
+type capturedOutput struct {
+	messages   []string
+	assertions []assertInfo
+	guidance   []guidanceInfo
+}
+
+func (c *capturedOutput) Output(message string) {
+	c.messages = append(c.messages, message)
+	var wrapped struct {
+		A *assertInfo   `json:"antithesis_assert"`
+		G *guidanceInfo `json:"antithesis_guidance"`
+	}
+	if err := json.Unmarshal([]byte(message), &wrapped); err != nil {
+		return
+	}
+	if wrapped.A != nil {
+		c.assertions = append(c.assertions, *wrapped.A)
+	}
+	if wrapped.G != nil {
+		c.guidance = append(c.guidance, *wrapped.G)
+	}
+}
+
+// captureOutput resets the trackers and collects emitted assertions until the test ends
+func captureOutput(t *testing.T) *capturedOutput {
+	internal.ResetTrackers()
+	c := &capturedOutput{}
+	internal.SetOutputHandler(c)
+	t.Cleanup(func() { internal.SetOutputHandler(nil) })
+	return c
+}
+
+func TestLazyDetailsOnlyEvaluatedWhenEmitted(t *testing.T) {
+	out := captureOutput(t)
+
+	calls := 0
+	for i := 0; i < 10; i++ {
+		details := LazyDetails(func() map[string]any {
+			calls++
+			return map[string]any{"i": i, "shared": "lazy"}
+		})
+		details["shared"] = "direct"
+		Always(i != 5, "lazy details", details)
+	}
+
+	if calls != 2 {
+		t.Errorf("details evaluated %d times, want 2", calls)
+	}
+	if len(out.assertions) != 2 {
+		t.Fatalf("emitted %d assertions, want 2", len(out.assertions))
+	}
+	failure := out.assertions[1].Details
+	if failure["i"] != float64(5) || failure["shared"] != "direct" {
+		t.Errorf("unexpected details %v", failure)
+	}
+	if _, ok := failure[lazyDetailsKey]; ok {
+		t.Errorf("lazy details were emitted unresolved: %v", failure)
+	}
+}
+
+func TestLazyDetailsInRichAssertions(t *testing.T) {
+	out := captureOutput(t)
+
+	calls := 0
+	details := LazyDetails(func() map[string]any {
+		calls++
+		return map[string]any{"left": "overridden", "extra": true}
+	})
+	for i := 0; i < 10; i++ {
+		AlwaysLessThan(i, 100, "lazy rich details", details)
+	}
+
+	if calls != 1 {
+		t.Errorf("details evaluated %d times, want 1", calls)
+	}
+	if len(out.assertions) != 1 {
+		t.Fatalf("emitted %d assertions, want 1", len(out.assertions))
+	}
+	got := out.assertions[0].Details
+	if got["left"] != float64(0) || got["right"] != float64(100) || got["extra"] != true {
+		t.Errorf("unexpected details %v", got)
+	}
+}
@@ -10,13 +10,13 @@ import (
 
 // stackFrameOffset indicates how many frames to go up in the
 // call stack to find the filename/location/line info.  As
-// this work is always done in NewLocationInfo(), the offset is
-// specified from the perspective of NewLocationInfo
+// this work is always done in callerLocation(), the offset is
+// specified from the perspective of callerLocation
 type stackFrameOffset int
 
 // Order is important here since iota is being used
 const (
-	offsetNewLocationInfo stackFrameOffset = iota
+	offsetCallerLocation stackFrameOffset = iota
 	offsetHere
 	offsetAPICaller
 	offsetAPICallersCaller
@@ -36,23 +36,59 @@ type locationInfo struct {
 // a locationInfo is not available
 const columnUnknown = 0
 
-// NewLocationInfo creates a locationInfo directly from
-// the current execution context
-func newLocationInfo(nframes stackFrameOffset) *locationInfo {
-	// Get location info and add to details
+// callsiteLocations caches the locationInfo of every call site seen so far, keyed by program counter
+var callsiteLocations readMostlyMap[uintptr, *locationInfo]
+
+// callerLocation returns the locationInfo for a call site, computing it only
+// the first time that the call site is seen. The returned locationInfo is
+// shared, and must not be modified.
+func callerLocation(nframes stackFrameOffset) *locationInfo {
+	// runtime.Callers counts itself as a frame, unlike runtime.Caller
+	var pcs [1]uintptr
+	if runtime.Callers(int(nframes)+1, pcs[:]) == 0 {
+		return &locationInfo{"*class*", "*function*", "*file*", 0, columnUnknown}
+	}
+	pc := pcs[0]
+	if loc, ok := callsiteLocations.load(pc); ok {
+		return loc
+	}
+	return callsiteLocations.loadOrCreate(pc, func() *locationInfo {
+		frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+		return locationFromFrame(frame)
+	})
+}
+
+func locationFromFrame(frame runtime.Frame) *locationInfo {
 	funcname := "*function*"
 	classname := "*class*"
-	pc, filename, line, ok := runtime.Caller(int(nframes))
-	if !ok {
+	filename := frame.File
+	line := frame.Line
+	if filename == "" {
 		filename = "*file*"
 		line = 0
-	} else {
-		if this_func := runtime.FuncForPC(pc); this_func != nil {
-			fullname := this_func.Name()
-			funcname = path.Ext(fullname)
-			classname, _ = strings.CutSuffix(fullname, funcname)
-			funcname = funcname[1:]
-		}
+	}
+	if fullname := frame.Function; fullname != "" {
+		funcname = path.Ext(fullname)
+		classname, _ = strings.CutSuffix(fullname, funcname)
+		funcname = funcname[1:]
 	}
 	return &locationInfo{classname, funcname, filename, line, columnUnknown}
 }
+
+// panicLocation returns the locationInfo of the function that panicked,
+// when called from a function deferred by it. The frames of the runtime,
+// which call the deferred function while panicking, are skipped.
+func panicLocation(nframes stackFrameOffset) *locationInfo {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(int(nframes)+1, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, "runtime.") {
+			return locationFromFrame(frame)
+		}
+		if !more {
+			return &locationInfo{"*class*", "*function*", "*file*", 0, columnUnknown}
+		}
+	}
+}
@@ -0,0 +1,31 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestCallerLocation(t *testing.T) {
+	out := captureOutput(t)
+
+	for i := 0; i < 2; i++ {
+		Always(i == 0, "location always", nil)
+		AlwaysLessThan(i, 0, "location always less than", nil)
+	}
+
+	if len(out.assertions) != 3 {
+		t.Fatalf("emitted %d assertions, want 3", len(out.assertions))
+	}
+	for _, a := range out.assertions {
+		loc := a.Location
+		if filepath.Base(loc.Filename) != "location_test.go" || loc.Funcname != "TestCallerLocation" ||
+			loc.Classname != "github.com/antithesishq/antithesis-sdk-go/assert" || loc.Line == 0 {
+			t.Errorf("unexpected location %+v for %q", *loc, a.Message)
+		}
+	}
+	if out.assertions[0].Location.Line != out.assertions[2].Location.Line {
+		t.Errorf("the pass and failure of one call site have different locations")
+	}
+}
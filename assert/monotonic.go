@@ -0,0 +1,103 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// monotonicValues keeps the last value seen for each key of a monotonicity assertion
+type monotonicValues[T Number] struct {
+	mutex sync.Mutex
+	last  map[string]T
+}
+
+// monotonicState is a *monotonicValues of any type
+type monotonicState interface {
+	valueType() string
+}
+
+func (values *monotonicValues[T]) valueType() string {
+	var zero T
+	return fmt.Sprintf("%T", zero)
+}
+
+// swap records value as the last value for key, and returns the value it replaces
+func (values *monotonicValues[T]) swap(key string, value T) (previous T, ok bool) {
+	values.mutex.Lock()
+	defer values.mutex.Unlock()
+	previous, ok = values.last[key]
+	values.last[key] = value
+	return previous, ok
+}
+
+// monotonicTracker keeps the values of each monotonicity assertion, so that
+// assertions with different messages do not contend with each other
+type monotonicTracker struct {
+	entries readMostlyMap[string, monotonicState]
+}
+
+var monotonic_tracker *monotonicTracker = &monotonicTracker{}
+
+func (tracker *monotonicTracker) reset() {
+	tracker.entries.rangeLocked(func(string, monotonicState) bool {
+		return false
+	})
+}
+
+// monotonic_values returns the values of the assertion with messageKey. The values of
+// each assertion are all of the type it was first called with: when they are not of
+// type T, the existing values are returned with ok set to false.
+func monotonic_values[T Number](messageKey string) (values *monotonicValues[T], state monotonicState, ok bool) {
+	state, found := monotonic_tracker.entries.load(messageKey)
+	if !found {
+		state = monotonic_tracker.entries.loadOrCreate(messageKey, func() monotonicState {
+			return &monotonicValues[T]{last: map[string]T{}}
+		})
+	}
+	values, ok = state.(*monotonicValues[T])
+	return values, state, ok
+}
+
+func monotonicImpl[T Number](ctx context.Context, loc *locationInfo, key string, value T, strict bool, message string, details map[string]any) {
+	id := makeKey(message, loc)
+	values, state, same_type := monotonic_values[T](id)
+	if !same_type {
+		// Values of different types cannot be compared, so the change of type is a failure
+		if assertTracker.mayEmit(id, loc, false) {
+			all_details := add_extra_details(with_context_details(ctx, details), map[string]any{"key": key, "current": value, "previous_type": state.valueType()})
+			assertImpl(false, message, all_details, loc, wasHit, mustBeHit, universalTest, alwaysDisplay, id)
+		}
+		return
+	}
+	previous, ok := values.swap(key, value)
+
+	// The first value for each key has nothing to be compared to
+	condition := !ok || value > previous || (!strict && value == previous)
+	if assertTracker.mayEmit(id, loc, condition) {
+		extra := map[string]any{"key": key, "current": value}
+		if ok {
+			extra["previous"] = previous
+		}
+		all_details := add_extra_details(with_context_details(ctx, details), extra)
+		assertImpl(condition, message, all_details, loc, wasHit, mustBeHit, universalTest, alwaysDisplay, id)
+	}
+
+	if ok {
+		numericGuidanceImpl(value, previous, message, id, loc, guidanceFnMinimize, wasHit)
+	}
+}
+
+// AlwaysMonotonic asserts that the values it is called with for each key never decrease. It is equivalent to asserting Always(value >= previous, message, details), where previous is the value it was last called with for the same key and message; the first value for each key always passes. Information about the values will automatically be added to the details parameter, with keys key, previous and current. Antithesis is guided towards values that are smaller than the previous ones, which may help it find more bugs.
+//
+// The last value for every key is kept for the life of the process, so keys should come from a bounded set, such as node identifiers. Every call with the same message must pass values of the same type: a call with values of another type fails, with the type of the earlier values added to the details under the key previous_type.
+func AlwaysMonotonic[T Number](key string, value T, message string, details map[string]any) {
+	monotonicImpl(context.Background(), callerLocation(offsetAPICaller), key, value, false, message, details)
+}
+
+// AlwaysStrictlyMonotonic is [AlwaysMonotonic] for values that must always increase. It is equivalent to asserting Always(value > previous, message, details).
+func AlwaysStrictlyMonotonic[T Number](key string, value T, message string, details map[string]any) {
+	monotonicImpl(context.Background(), callerLocation(offsetAPICaller), key, value, true, message, details)
+}
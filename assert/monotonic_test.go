@@ -0,0 +1,67 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"testing"
+)
+
+func TestAlwaysMonotonic(t *testing.T) {
+	out := captureOutput(t)
+
+	AlwaysMonotonic("n1", 1, "monotonic", nil)
+	AlwaysMonotonic("n2", 7, "monotonic", nil)
+	AlwaysMonotonic("n1", 1, "monotonic", nil)
+	AlwaysMonotonic("n1", 4, "monotonic", nil)
+	AlwaysMonotonic("n1", 3, "monotonic", nil)
+	AlwaysMonotonic("n1", 5, "monotonic", nil)
+
+	got := conditions(out.assertions)
+	if len(got) != 2 || !got[0] || got[1] {
+		t.Fatalf("emitted conditions %v, want [true false]", got)
+	}
+	failure := out.assertions[1].Details
+	if failure["key"] != "n1" || failure["previous"] != float64(4) || failure["current"] != float64(3) {
+		t.Errorf("unexpected details %v", failure)
+	}
+	if s := summaryFor(t, "monotonic"); s.PassCount != 5 || s.FailCount != 1 {
+		t.Errorf("unexpected summary %+v", s)
+	}
+}
+
+func TestAlwaysStrictlyMonotonic(t *testing.T) {
+	out := captureOutput(t)
+
+	AlwaysStrictlyMonotonic("k", 1.5, "strictly monotonic", nil)
+	AlwaysStrictlyMonotonic("k", 2.5, "strictly monotonic", nil)
+	AlwaysStrictlyMonotonic("k", 2.5, "strictly monotonic", nil)
+
+	got := conditions(out.assertions)
+	if len(got) != 2 || !got[0] || got[1] {
+		t.Fatalf("emitted conditions %v, want [true false]", got)
+	}
+	if len(out.guidance) != 2 {
+		t.Errorf("sent guidance %d times, want 2", len(out.guidance))
+	}
+}
+
+func TestAlwaysMonotonicTypeChange(t *testing.T) {
+	out := captureOutput(t)
+
+	for _, value := range []any{int64(1), int32(2), int64(3)} {
+		switch v := value.(type) {
+		case int64:
+			AlwaysMonotonic("k", v, "monotonic type change", nil)
+		case int32:
+			AlwaysMonotonic("k", v, "monotonic type change", nil)
+		}
+	}
+
+	got := conditions(out.assertions)
+	if len(got) != 2 || !got[0] || got[1] {
+		t.Fatalf("emitted conditions %v, want [true false]", got)
+	}
+	if failure := out.assertions[1].Details; failure["previous_type"] != "int64" || failure["current"] != float64(2) {
+		t.Errorf("unexpected details %v", failure)
+	}
+}
@@ -0,0 +1,561 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+const (
+	defaultDetailsMaxDepth = 32
+	defaultDetailsMaxBytes = 1 << 20
+
+	cycleMarker    = "[cycle]"
+	maxDepthMarker = "[max depth exceeded]"
+	truncatedKey   = "[truncated]"
+)
+
+var (
+	detailsMaxDepth atomic.Int64
+	detailsMaxBytes atomic.Int64
+)
+
+func init() {
+	detailsMaxDepth.Store(defaultDetailsMaxDepth)
+	detailsMaxBytes.Store(defaultDetailsMaxBytes)
+}
+
+// SetDetailsLimits limits the size of the details reported with every assertion, so that a very large or deeply nested details value cannot prevent the assertion from being reported. Values nested more than maxDepth levels deep are replaced with "[max depth exceeded]", and once the details reach roughly maxBytes of JSON, long strings are cut short and the remaining elements of collections are replaced with a count of what was left out. A limit of zero or less restores the default, which is a depth of 32 and 1 MiB.
+func SetDetailsLimits(maxDepth, maxBytes int) {
+	if maxDepth <= 0 {
+		maxDepth = defaultDetailsMaxDepth
+	}
+	if maxBytes <= 0 {
+		maxBytes = defaultDetailsMaxBytes
+	}
+	detailsMaxDepth.Store(int64(maxDepth))
+	detailsMaxBytes.Store(int64(maxBytes))
+}
+
+var (
+	detailerType      = reflect.TypeFor[Detailer]()
+	redactableType    = reflect.TypeFor[Redactable]()
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+	errorType         = reflect.TypeFor[error]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+	logValuerType     = reflect.TypeFor[slog.LogValuer]()
+	stringerType      = reflect.TypeFor[fmt.Stringer]()
+	jsonNumberType    = reflect.TypeFor[json.Number]()
+)
+
+// normalizer makes a deep copy of a details value that encoding/json can always marshal:
+// values are replaced as described by Detailer, secrets are redacted, cycles, excess depth and excess size are
+// replaced with markers, and values that encoding/json rejects become strings.
+type normalizer struct {
+	maxDepth  int
+	remaining int               // bytes left before truncating
+	redaction *redaction        // nil when nothing is redacted
+	visiting  map[visitKey]bool // the references on the path to the current value
+	fields    map[reflect.Type][]structField
+}
+
+// visitKey identifies a reference, so that a cycle is detected when it is visited again
+// inside itself. Slices sharing an array with different lengths are different references.
+type visitKey struct {
+	ptr    uintptr
+	typ    reflect.Type
+	length int
+}
+
+func newNormalizer() *normalizer {
+	return &normalizer{
+		maxDepth:  int(detailsMaxDepth.Load()),
+		remaining: int(detailsMaxBytes.Load()),
+		redaction: current_redaction.Load(),
+	}
+}
+
+// Recursively replace any `error` with its message, along with anything else that would
+// prevent marshaling, while doing a deep copy. normalizeMap localizes the type assertion
+// and takes in/out a map instead of any.
+func normalizeMap(v map[string]any) map[string]any {
+	out, _ := normalize(v).(map[string]any)
+	return out
+}
+
+func normalize(input any) any {
+	return newNormalizer().value(reflect.ValueOf(input), 0)
+}
+
+func (n *normalizer) value(v reflect.Value, depth int) any {
+	if !v.IsValid() {
+		n.remaining -= 4
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Map, reflect.Slice:
+		if v.IsNil() {
+			n.remaining -= 4
+			return nil
+		}
+	}
+	if v.Kind() == reflect.Interface {
+		// The methods of the dynamic value are the ones that matter, not those of the
+		// interface. As fmt does, an error holding a nil pointer is reported as its
+		// Error method reports it, or as "<nil>" when that method panics.
+		elem := v.Elem()
+		if elem.Kind() == reflect.Pointer && elem.IsNil() && v.Type().Implements(errorType) && v.CanInterface() {
+			return n.string(fmt.Sprintf("%+v", elem.Interface()))
+		}
+		return n.value(elem, depth)
+	}
+
+	// As with encoding/json, a json.Number is reported as the number it holds, so that
+	// numbers decoded from the output of a json.Marshaler keep their precision
+	if v.Type() == jsonNumberType && valid_number(v.String()) {
+		n.remaining -= v.Len()
+		return json.Number(v.String())
+	}
+
+	// Methods cannot be called on values promoted from unexported embedded structs,
+	// so those are only walked by kind
+	if v.CanInterface() {
+		if r, ok := implementer[Redactable](v, redactableType); ok {
+			if r.AntithesisRedact() {
+				return n.string(redactedMarker)
+			}
+			// Patterns are not applied to anything inside the value either
+			defer func(saved *redaction) { n.redaction = saved }(n.redaction)
+			n.redaction = nil
+		}
+		if out, ok := n.methods(v, depth); ok {
+			return out
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		n.remaining -= 5
+		return v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n.remaining -= 8
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n.remaining -= 8
+		return v.Uint()
+	case reflect.Float32, reflect.Float64:
+		return n.float(v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		return n.string(fmt.Sprint(v.Complex()))
+	case reflect.String:
+		return n.string(v.String())
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return n.string(fmt.Sprintf("[%s]", v.Type()))
+	}
+
+	if depth >= n.maxDepth {
+		return n.string(maxDepthMarker)
+	}
+	switch v.Kind() {
+	case reflect.Pointer:
+		return n.reference(v, 0, func() any { return n.value(v.Elem(), depth+1) })
+	case reflect.Map:
+		return n.reference(v, 0, func() any { return n.mapValue(v, depth+1) })
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			// Like encoding/json, byte slices are encoded as base64
+			return n.string(base64.StdEncoding.EncodeToString(v.Bytes()))
+		}
+		return n.reference(v, v.Len(), func() any { return n.list(v, depth+1) })
+	case reflect.Array:
+		return n.list(v, depth+1)
+	case reflect.Struct:
+		return n.structValue(v, depth+1)
+	}
+	return n.string(fmt.Sprintf("[%s]", v.Type()))
+}
+
+// methods normalizes values whose types control how they are reported. A panic in one of
+// those methods is reported in place of the value.
+func (n *normalizer) methods(v reflect.Value, depth int) (out any, ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			out, ok = n.string(fmt.Sprintf("[panic: %v]", r)), true
+		}
+	}()
+
+	if d, ok := implementer[Detailer](v, detailerType); ok {
+		if depth >= n.maxDepth {
+			return n.string(maxDepthMarker), true
+		}
+		return n.value(reflect.ValueOf(d.AntithesisDetails()), depth+1), true
+	}
+	// Check if the value implements json.Marshaler, so that if it already knows how to
+	// marshal itself, we don't override that.
+	if m, ok := implementer[json.Marshaler](v, jsonMarshalerType); ok {
+		return n.marshaled(m), true
+	}
+	if v.Type().Implements(errorType) {
+		// Marshal errors as their debug output string instead of Error(). These should be
+		// equivalent, but Sprintf correctly handles nil receivers for us (which otherwise
+		// are annoying to defend against due to this - https://go.dev/doc/faq#nil_error)
+		return n.string(fmt.Sprintf("%+v", v.Interface())), true
+	}
+	if m, ok := implementer[encoding.TextMarshaler](v, textMarshalerType); ok {
+		text, err := m.MarshalText()
+		if err != nil {
+			return n.string(fmt.Sprintf("[%v]", err)), true
+		}
+		return n.string(string(text)), true
+	}
+	if lv, ok := implementer[slog.LogValuer](v, logValuerType); ok {
+		return n.logValue(lv.LogValue().Resolve(), depth), true
+	}
+	if lv, ok := v.Interface().(slog.Value); ok {
+		return n.logValue(lv.Resolve(), depth), true
+	}
+	// Structs are reported field by field, as encoding/json does, even when they have a
+	// String method, since it usually describes them less fully
+	if s, ok := implementer[fmt.Stringer](v, stringerType); ok && !is_struct(v.Type()) {
+		return n.string(s.String()), true
+	}
+	return nil, false
+}
+
+// is_struct reports whether t is a struct, or a pointer to one
+func is_struct(t reflect.Type) bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct
+}
+
+// logValue normalizes a resolved slog.Value, with groups becoming objects
+func (n *normalizer) logValue(lv slog.Value, depth int) any {
+	switch lv.Kind() {
+	case slog.KindGroup:
+		if depth >= n.maxDepth {
+			return n.string(maxDepthMarker)
+		}
+		attrs := lv.Group()
+		out := make(map[string]any, len(attrs))
+		for i, attr := range attrs {
+			if n.remaining <= 0 {
+				out[truncatedKey] = fmt.Sprintf("[truncated %d attributes]", len(attrs)-i)
+				break
+			}
+			n.field(out, attr.Key, func() any { return n.logValue(attr.Value.Resolve(), depth+1) })
+		}
+		return out
+	case slog.KindAny:
+		if depth >= n.maxDepth {
+			return n.string(maxDepthMarker)
+		}
+		return n.value(reflect.ValueOf(lv.Any()), depth+1)
+	}
+	return n.value(reflect.ValueOf(lv.Any()), depth)
+}
+
+// implementer returns v as an I when it, or a pointer to it, implements I
+func implementer[I any](v reflect.Value, iType reflect.Type) (I, bool) {
+	var zero I
+	if v.Type().Implements(iType) {
+		i, ok := v.Interface().(I)
+		return i, ok
+	}
+	if v.Kind() != reflect.Pointer && v.CanAddr() && reflect.PointerTo(v.Type()).Implements(iType) {
+		i, ok := v.Addr().Interface().(I)
+		return i, ok
+	}
+	return zero, false
+}
+
+// reference visits a pointer, map or slice, unless it is already being visited
+func (n *normalizer) reference(v reflect.Value, length int, visit func() any) any {
+	key := visitKey{ptr: uintptr(v.UnsafePointer()), typ: v.Type(), length: length}
+	if n.visiting[key] {
+		return n.string(cycleMarker)
+	}
+	if n.visiting == nil {
+		n.visiting = map[visitKey]bool{}
+	}
+	n.visiting[key] = true
+	defer delete(n.visiting, key)
+	return visit()
+}
+
+// valid_number reports whether s is a JSON number
+func valid_number(s string) bool {
+	return s != "" && (s[0] == '-' || ('0' <= s[0] && s[0] <= '9')) && json.Valid([]byte(s))
+}
+
+func (n *normalizer) marshaled(m json.Marshaler) any {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return n.string(fmt.Sprintf("[%v]", err))
+	}
+	if n.redaction != nil {
+		// Redaction applies to the keys and strings in the marshaled value too
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		var decoded any
+		if err := decoder.Decode(&decoded); err != nil {
+			return n.string(fmt.Sprintf("[%v]", err))
+		}
+		return n.value(reflect.ValueOf(decoded), 0)
+	}
+	if len(data) > n.remaining {
+		return n.string(fmt.Sprintf("[truncated %d bytes]", len(data)))
+	}
+	n.remaining -= len(data)
+	return json.RawMessage(data)
+}
+
+// field adds the value for key to out, unless key is redacted
+func (n *normalizer) field(out map[string]any, key string, value func() any) {
+	n.remaining -= len(key) + 4
+	if n.redaction.redactsKey(key) {
+		out[key] = n.string(redactedMarker)
+		return
+	}
+	out[key] = value()
+}
+
+// float converts NaN and the infinities, which JSON cannot represent, to strings
+func (n *normalizer) float(f float64) any {
+	switch {
+	case math.IsNaN(f):
+		return n.string("NaN")
+	case math.IsInf(f, 1):
+		return n.string("+Inf")
+	case math.IsInf(f, -1):
+		return n.string("-Inf")
+	}
+	n.remaining -= 8
+	return f
+}
+
+// string returns s, cut short when it exceeds the bytes that remain
+func (n *normalizer) string(s string) any {
+	s = n.redaction.redactValue(s)
+	if len(s) <= n.remaining {
+		n.remaining -= len(s) + 2
+		return s
+	}
+	keep := max(n.remaining, 0)
+	for keep > 0 && !utf8.RuneStart(s[keep]) {
+		keep--
+	}
+	n.remaining = -1
+	return s[:keep] + fmt.Sprintf("[truncated %d bytes]", len(s)-keep)
+}
+
+func (n *normalizer) list(v reflect.Value, depth int) any {
+	out := make([]any, 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		if n.remaining <= 0 {
+			out = append(out, fmt.Sprintf("[truncated %d elements]", v.Len()-i))
+			break
+		}
+		out = append(out, n.value(v.Index(i), depth))
+	}
+	return out
+}
+
+func (n *normalizer) mapValue(v reflect.Value, depth int) any {
+	// Keys are visited in the order they are marshaled, so that the same entries are
+	// kept whenever the map is truncated
+	type entry struct {
+		key   string
+		value reflect.Value
+	}
+	entries := make([]entry, 0, v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		entries = append(entries, entry{mapKey(iter.Key()), iter.Value()})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].key < entries[j].key })
+
+	out := make(map[string]any, len(entries))
+	for i, e := range entries {
+		if n.remaining <= 0 {
+			out[truncatedKey] = fmt.Sprintf("[truncated %d entries]", len(entries)-i)
+			break
+		}
+		n.field(out, e.key, func() any { return n.value(e.value, depth) })
+	}
+	return out
+}
+
+// mapKey converts k to a string the same way encoding/json does, falling back to its
+// default format for key types that encoding/json rejects
+func mapKey(k reflect.Value) string {
+	if k.Kind() == reflect.String {
+		return k.String()
+	}
+	if k.CanInterface() && k.Kind() != reflect.Interface && !(k.Kind() == reflect.Pointer && k.IsNil()) {
+		if m, ok := k.Interface().(encoding.TextMarshaler); ok {
+			if text, err := m.MarshalText(); err == nil {
+				return string(text)
+			}
+		}
+	}
+	switch k.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(k.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(k.Uint(), 10)
+	case reflect.Interface:
+		if !k.IsNil() {
+			return mapKey(k.Elem())
+		}
+	}
+	if k.CanInterface() {
+		return fmt.Sprint(k.Interface())
+	}
+	return fmt.Sprintf("[%s]", k.Type())
+}
+
+// structField is a field marshaled by encoding/json
+type structField struct {
+	name      string
+	index     []int
+	omitEmpty bool
+}
+
+func (n *normalizer) structValue(v reflect.Value, depth int) any {
+	if n.fields == nil {
+		n.fields = map[reflect.Type][]structField{}
+	}
+	fields, ok := n.fields[v.Type()]
+	if !ok {
+		fields = structFields(v.Type())
+		n.fields[v.Type()] = fields
+	}
+
+	out := make(map[string]any, len(fields))
+	for i, f := range fields {
+		if n.remaining <= 0 {
+			out[truncatedKey] = fmt.Sprintf("[truncated %d fields]", len(fields)-i)
+			break
+		}
+		fv, ok := fieldByIndex(v, f.index)
+		if !ok || (f.omitEmpty && isEmptyValue(fv)) {
+			continue
+		}
+		n.field(out, f.name, func() any { return n.value(fv, depth) })
+	}
+	return out
+}
+
+// structFields lists the fields of t that encoding/json marshals, with their JSON names.
+// Fields of embedded structs are promoted unless a shallower field has the same name.
+func structFields(t reflect.Type) []structField {
+	var fields []structField
+	seen := map[string]bool{}
+	current := []structField{{index: nil}}
+	visited := map[reflect.Type]bool{}
+	for len(current) > 0 {
+		var next []structField
+		var level []structField
+		for _, embedded := range current {
+			st := t
+			if embedded.index != nil {
+				st = t.FieldByIndex(embedded.index).Type
+				if st.Kind() == reflect.Pointer {
+					st = st.Elem()
+				}
+			}
+			if visited[st] {
+				continue
+			}
+			visited[st] = true
+			for i := 0; i < st.NumField(); i++ {
+				sf := st.Field(i)
+				index := append(append([]int{}, embedded.index...), i)
+				tag := sf.Tag.Get("json")
+				if tag == "-" {
+					continue
+				}
+				name, opts, _ := strings.Cut(tag, ",")
+				ft := sf.Type
+				if ft.Kind() == reflect.Pointer {
+					ft = ft.Elem()
+				}
+				if sf.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+					next = append(next, structField{index: index})
+					continue
+				}
+				if !sf.IsExported() {
+					continue
+				}
+				if name == "" {
+					name = sf.Name
+				}
+				level = append(level, structField{name: name, index: index, omitEmpty: strings.Contains(opts, "omitempty")})
+			}
+		}
+		// Fields at the same depth with the same name cancel each other out
+		count := map[string]int{}
+		for _, f := range level {
+			count[f.name]++
+		}
+		for _, f := range level {
+			if !seen[f.name] && count[f.name] == 1 {
+				fields = append(fields, f)
+			}
+		}
+		for name := range count {
+			seen[name] = true
+		}
+		current = next
+	}
+	sort.SliceStable(fields, func(i, j int) bool { return lessIndex(fields[i].index, fields[j].index) })
+	return fields
+}
+
+func lessIndex(a, b []int) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return len(a) < len(b)
+}
+
+// fieldByIndex is reflect.Value.FieldByIndex, except that it reports a nil embedded pointer
+// instead of panicking
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Pointer, reflect.Interface:
+		return v.IsNil()
+	}
+	return v.IsZero()
+}
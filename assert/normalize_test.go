@@ -0,0 +1,246 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"net"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+)
+
+func normalizedJSON(t *testing.T, v any) string {
+	t.Helper()
+	data, err := json.Marshal(normalize(v))
+	if err != nil {
+		t.Fatalf("normalized %T does not marshal: %v", v, err)
+	}
+	return string(data)
+}
+
+func checkNormalized(t *testing.T, v any, want string) {
+	t.Helper()
+	if got := normalizedJSON(t, v); got != want {
+		t.Errorf("normalized %T marshaled to %s, want %s", v, got, want)
+	}
+}
+
+type wrapsError struct {
+	Op  string
+	Err error
+}
+
+type embedded struct {
+	Inner string
+	Name  string `json:"name"`
+}
+
+type withTags struct {
+	embedded
+	Name    string `json:"name"`
+	Skipped string `json:"-"`
+	Empty   string `json:",omitempty"`
+	private string
+}
+
+func TestNormalizeErrors(t *testing.T) {
+	err := errors.New("boom")
+	checkNormalized(t, wrapsError{"read", err}, `{"Err":"boom","Op":"read"}`)
+	checkNormalized(t, &wrapsError{"read", err}, `{"Err":"boom","Op":"read"}`)
+	checkNormalized(t, map[string]error{"a": err, "b": nil}, `{"a":"boom","b":null}`)
+	checkNormalized(t, []error{err}, `["boom"]`)
+	checkNormalized(t, map[int][]any{1: {wrapsError{Err: err}}}, `{"1":[{"Err":"boom","Op":""}]}`)
+}
+
+func TestNormalizeStructs(t *testing.T) {
+	v := withTags{embedded: embedded{Inner: "in", Name: "hidden"}, Name: "outer", Skipped: "x", private: "p"}
+	checkNormalized(t, v, `{"Inner":"in","name":"outer"}`)
+	checkNormalized(t, struct{ Ch chan int }{}, `{"Ch":"[chan int]"}`)
+	checkNormalized(t, struct{ Fn func() }{func() {}}, `{"Fn":"[func()]"}`)
+	checkNormalized(t, []byte("hi"), `"aGk="`)
+}
+
+func TestNormalizeMarshalers(t *testing.T) {
+	when := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	checkNormalized(t, map[string]any{"when": when}, `{"when":"2024-01-02T03:04:05Z"}`)
+	checkNormalized(t, net.IPv4(10, 0, 0, 1), `"10.0.0.1"`)
+	checkNormalized(t, map[netip.Addr]int{netip.MustParseAddr("10.0.0.1"): 1}, `{"10.0.0.1":1}`)
+	checkNormalized(t, []json.Number{"12345678901234567891", "1.5e300", "NaN"}, `[12345678901234567891,1.5e300,"NaN"]`)
+}
+
+func TestNormalizeFloats(t *testing.T) {
+	checkNormalized(t, []any{math.NaN(), math.Inf(1), math.Inf(-1), float32(1.5)}, `["NaN","+Inf","-Inf",1.5]`)
+	checkNormalized(t, complex(1, 2), `"(1+2i)"`)
+}
+
+type node struct {
+	Name string
+	Next *node
+}
+
+func TestNormalizeCycles(t *testing.T) {
+	n := &node{Name: "a"}
+	n.Next = n
+	checkNormalized(t, n, `{"Name":"a","Next":"[cycle]"}`)
+
+	m := map[string]any{}
+	m["self"] = m
+	checkNormalized(t, m, `{"self":"[cycle]"}`)
+
+	s := []any{nil}
+	s[0] = s
+	checkNormalized(t, s, `["[cycle]"]`)
+
+	// A value referenced twice is not a cycle
+	shared := &node{Name: "shared"}
+	checkNormalized(t, []*node{shared, shared}, `[{"Name":"shared","Next":null},{"Name":"shared","Next":null}]`)
+}
+
+func TestNormalizeLimits(t *testing.T) {
+	t.Cleanup(func() { SetDetailsLimits(0, 0) })
+
+	SetDetailsLimits(2, 0)
+	nested := map[string]any{"a": map[string]any{"b": map[string]any{"c": 1}}}
+	checkNormalized(t, nested, `{"a":{"b":"[max depth exceeded]"}}`)
+
+	SetDetailsLimits(0, 100)
+	long := strings.Repeat("x", 1000)
+	got := normalizedJSON(t, map[string]any{"long": long})
+	if len(got) > 200 || !strings.Contains(got, "truncated") {
+		t.Errorf("long string marshaled to %s", got)
+	}
+
+	many := make([]int, 1000)
+	got = normalizedJSON(t, many)
+	if len(got) > 200 || !strings.Contains(got, "truncated") {
+		t.Errorf("long slice marshaled to %s", got)
+	}
+
+	// A deep cyclic structure stays within the limits
+	SetDetailsLimits(0, 0)
+	var list *node
+	for i := 0; i < 100; i++ {
+		list = &node{Name: "n", Next: list}
+	}
+	got = normalizedJSON(t, list)
+	if !strings.Contains(got, "max depth exceeded") {
+		t.Errorf("deep list marshaled to %s", got)
+	}
+}
+
+func TestAssertionWithUnmarshalableDetails(t *testing.T) {
+	out := captureOutput(t)
+
+	details := map[string]any{"ratio": math.NaN(), "err": &wrapsError{"write", errors.New("disk full")}}
+	details["self"] = details
+	Always(false, "unmarshalable details", details)
+
+	if len(out.assertions) != 1 {
+		t.Fatalf("emitted %d assertions, want 1", len(out.assertions))
+	}
+	got := out.assertions[0].Details
+	if got["ratio"] != "NaN" || got["self"] != cycleMarker {
+		t.Errorf("unexpected details %v", got)
+	}
+	if err, ok := got["err"].(map[string]any); !ok || err["Err"] != "disk full" {
+		t.Errorf("unexpected details %v", got)
+	}
+}
+
+type peer struct {
+	ID    int
+	Peers []*peer
+}
+
+func (p *peer) AntithesisDetails() any {
+	return map[string]any{"id": p.ID, "peers": len(p.Peers)}
+}
+
+type color int
+
+func (c color) String() string {
+	return [...]string{"red", "green"}[c]
+}
+
+type point struct {
+	X, Y int
+}
+
+func (p point) String() string {
+	return fmt.Sprintf("(%d, %d)", p.X, p.Y)
+}
+
+type account struct {
+	Name     string
+	Password string
+}
+
+func (a account) LogValue() slog.Value {
+	return slog.GroupValue(slog.String("name", a.Name), slog.Any("color", color(1)))
+}
+
+type recursiveDetailer struct{}
+
+func (r recursiveDetailer) AntithesisDetails() any {
+	return r
+}
+
+type detailedError struct {
+	Code int
+}
+
+func (e *detailedError) Error() string {
+	return fmt.Sprintf("detailed error %d", e.Code)
+}
+
+func (e *detailedError) AntithesisDetails() any {
+	return map[string]any{"code": e.Code}
+}
+
+type jsonError struct{}
+
+func (jsonError) Error() string {
+	return "json error"
+}
+
+func (jsonError) MarshalJSON() ([]byte, error) {
+	return []byte(`{"kind":"json"}`), nil
+}
+
+func TestNormalizeInterfaceValues(t *testing.T) {
+	// The methods of the value held by an interface are used, rather than those of the interface
+	checkNormalized(t, wrapsError{"read", &detailedError{7}}, `{"Err":{"code":7},"Op":"read"}`)
+	checkNormalized(t, map[string]error{"json": jsonError{}}, `{"json":{"kind":"json"}}`)
+	checkNormalized(t, []fmt.Stringer{color(1)}, `["green"]`)
+
+	// As with fmt, an error holding a nil pointer is reported as "<nil>"
+	var nilError *detailedError
+	checkNormalized(t, map[string]error{"nil": nilError}, `{"nil":"\u003cnil\u003e"}`)
+}
+
+func TestNormalizeDetailers(t *testing.T) {
+	a := &peer{ID: 1}
+	b := &peer{ID: 2, Peers: []*peer{a}}
+	a.Peers = []*peer{b}
+	checkNormalized(t, map[string]any{"node": a}, `{"node":{"id":1,"peers":1}}`)
+
+	// As with encoding/json, methods with pointer receivers are only used when the value is addressable
+	checkNormalized(t, struct{ P peer }{peer{ID: 3}}, `{"P":{"ID":3,"Peers":null}}`)
+	checkNormalized(t, &struct{ P peer }{peer{ID: 3}}, `{"P":{"id":3,"peers":0}}`)
+
+	checkNormalized(t, []color{0, 1}, `["red","green"]`)
+	checkNormalized(t, map[string]any{"point": point{1, 2}, "ptr": &point{3, 4}}, `{"point":{"X":1,"Y":2},"ptr":{"X":3,"Y":4}}`)
+	checkNormalized(t, account{"alice", "secret"}, `{"color":"green","name":"alice"}`)
+	checkNormalized(t, color(7), `"[panic: runtime error: index out of range [7] with length 2]"`)
+
+	got := normalizedJSON(t, recursiveDetailer{})
+	if !strings.Contains(got, "max depth exceeded") {
+		t.Errorf("recursive detailer marshaled to %s", got)
+	}
+}
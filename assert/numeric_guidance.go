@@ -5,6 +5,7 @@ package assert
 import (
 	"math"
 	"sync"
+	"sync/atomic"
 
 	"github.com/antithesishq/antithesis-sdk-go/internal"
 )
@@ -43,53 +44,64 @@ func gapTypeForOperand[T Number](num T) numericGapType {
 // For GuidanceFnMinimize:
 //   - gap is the most negative value sent so far
 //
+// The gap is replaced (never modified) when a better one is sent, so that
+// it can be compared against without holding a lock.
 // --------------------------------------------------------------------------------
 type numericGuidanceInfo struct {
-	gap           any
+	gap           atomic.Pointer[numericGap]
 	descriminator numericGapType
 	maximize      bool
 }
 
-type numericGuidanceTracker map[string]*numericGuidanceInfo
+// numericGap holds the gap of either an integer or a floating point tracker
+type numericGap struct {
+	gap       gapValue[uint64]
+	float_gap gapValue[float64]
+}
+
+type numericGuidanceTracker struct {
+	entries readMostlyMap[string, *numericGuidanceInfo]
+}
 
 var (
-	numeric_guidance_tracker       numericGuidanceTracker = make(numericGuidanceTracker)
-	numeric_guidance_tracker_mutex sync.Mutex
-	numeric_guidance_info_mutex    sync.Mutex
+	numeric_guidance_tracker    *numericGuidanceTracker = &numericGuidanceTracker{}
+	numeric_guidance_info_mutex sync.Mutex
 )
 
-func (tracker numericGuidanceTracker) getTrackerEntry(messageKey string, trackerType numericGapType, maximize bool) *numericGuidanceInfo {
-	var trackerEntry *numericGuidanceInfo
-	var ok bool
-
+func (tracker *numericGuidanceTracker) getTrackerEntry(messageKey string, trackerType numericGapType, maximize bool) *numericGuidanceInfo {
 	if tracker == nil {
 		return nil
 	}
 
-	numeric_guidance_tracker_mutex.Lock()
-	defer numeric_guidance_tracker_mutex.Unlock()
-	if trackerEntry, ok = numeric_guidance_tracker[messageKey]; !ok {
-		trackerEntry = newNumericGuidanceInfo(trackerType, maximize)
-		tracker[messageKey] = trackerEntry
+	if trackerEntry, ok := tracker.entries.load(messageKey); ok {
+		return trackerEntry
 	}
+	return tracker.entries.loadOrCreate(messageKey, func() *numericGuidanceInfo {
+		return newNumericGuidanceInfo(trackerType, maximize)
+	})
+}
 
-	return trackerEntry
+// reset forgets the gaps sent so far
+func (tracker *numericGuidanceTracker) reset() {
+	tracker.entries.rangeLocked(func(string, *numericGuidanceInfo) bool {
+		return false
+	})
 }
 
 // Create an numeric guidance entry
 func newNumericGuidanceInfo(trackerType numericGapType, maximize bool) *numericGuidanceInfo {
 
-	var gap any
+	var gap numericGap
 	if trackerType == integerGapType {
-		gap = newGapValue(uint64(math.MaxUint64), maximize)
+		gap.gap = gapValue[uint64]{gap_size: math.MaxUint64, gap_is_negative: maximize}
 	} else {
-		gap = newGapValue(float64(math.MaxFloat64), maximize)
+		gap.float_gap = gapValue[float64]{gap_size: math.MaxFloat64, gap_is_negative: maximize}
 	}
 	trackerInfo := numericGuidanceInfo{
 		maximize:      maximize,
 		descriminator: trackerType,
-		gap:           gap,
 	}
+	trackerInfo.gap.Store(&gap)
 	return &trackerInfo
 }
 
@@ -175,14 +187,41 @@ func is_less_than[T numConstraint](left gapValue[T], right gapValue[T]) bool {
 	return true
 }
 
-func send_value_if_needed(tI *numericGuidanceInfo, gI *guidanceInfo) {
+// improves reports whether gap is better than the best gap sent so far
+func (tI *numericGuidanceInfo) improves(gap numericGap) bool {
+	prev := tI.gap.Load()
+	if tI.is_integer_gap() {
+		if tI.should_maximize() {
+			return is_greater_than(gap.gap, prev.gap)
+		}
+		return is_less_than(gap.gap, prev.gap)
+	}
+	if tI.should_maximize() {
+		return is_greater_than(gap.float_gap, prev.float_gap)
+	}
+	return is_less_than(gap.float_gap, prev.float_gap)
+}
+
+// numericGapFor computes the gap between left and right without allocating
+func numericGapFor[T Number](left, right T) numericGap {
+	switch any(left).(type) {
+	case int8, int16, int32:
+		return numericGap{gap: makeGap(numericOperands[int32]{int32(left), int32(right)})}
+	case int, int64:
+		return numericGap{gap: makeGap(numericOperands[int64]{int64(left), int64(right)})}
+	case uint8, uint16, uint32, uint, uint64, uintptr:
+		return numericGap{gap: makeGap(numericOperands[uint64]{uint64(left), uint64(right)})}
+	case float32, float64:
+		return numericGap{float_gap: makeFloatGap(numericOperands[float64]{float64(left), float64(right)})}
+	}
+	return numericGap{}
+}
+
+func send_value_if_needed(tI *numericGuidanceInfo, gI *guidanceInfo, gap numericGap) {
 	if tI == nil {
 		return
 	}
 
-	numeric_guidance_info_mutex.Lock()
-	defer numeric_guidance_info_mutex.Unlock()
-
 	// if this is a catalog entry (gI.hit is false)
 	// do not update the reference gap in the tracker (tI *numericGuidanceInfo)
 	if !gI.Hit {
@@ -190,59 +229,17 @@ func send_value_if_needed(tI *numericGuidanceInfo, gI *guidanceInfo) {
 		return
 	}
 
-	should_send := false
-	maximize := tI.should_maximize()
-
-	var gap gapValue[uint64]
-	var float_gap gapValue[float64]
-
-	// Needs to have individual case statements to assist
-	// the compiler to infer the actual type of the var named 'operands'
-	switch operands := (gI.Data).(type) {
-	case numericOperands[int32]:
-		gap = makeGap(operands)
-	case numericOperands[int64]:
-		gap = makeGap(operands)
-	case numericOperands[uint64]:
-		gap = makeGap(operands)
-	case numericOperands[float64]:
-		float_gap = makeFloatGap(operands)
-	}
-
-	var prev_gap gapValue[uint64]
-	var prev_float_gap gapValue[float64]
-	has_prev_gap := false
-	has_prev_float_gap := false
-
-	prev_gap, has_prev_gap = tI.gap.(gapValue[uint64])
-	if !has_prev_gap {
-		prev_float_gap, has_prev_float_gap = tI.gap.(gapValue[float64])
-	}
-
-	if has_prev_gap {
-		if maximize {
-			should_send = is_greater_than(gap, prev_gap)
-		} else {
-			should_send = is_less_than(gap, prev_gap)
-		}
-	}
-
-	if has_prev_float_gap {
-		if maximize {
-			should_send = is_greater_than(float_gap, prev_float_gap)
-		} else {
-			should_send = is_less_than(float_gap, prev_float_gap)
-		}
-	}
-
-	if should_send {
-		if tI.is_integer_gap() {
-			tI.gap = gap
-		} else {
-			tI.gap = float_gap
-		}
-		emitGuidance(gI)
+	// The guidance is marshaled while holding the lock, and emitted once it
+	// has been released, so that handlers may evaluate assertions
+	var out internal.Output
+	numeric_guidance_info_mutex.Lock()
+	// Another caller may have sent a better gap since it was last checked
+	if tI.improves(gap) {
+		tI.gap.Store(&gap)
+		out = internal.Prepare_json(map[string]any{"antithesis_guidance": gI})
 	}
+	numeric_guidance_info_mutex.Unlock()
+	out.Emit()
 }
 
 func emitGuidance(gI *guidanceInfo) error {
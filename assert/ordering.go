@@ -0,0 +1,142 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// orderingProperty is a happens-before property declared with AlwaysHappensBefore
+type orderingProperty struct {
+	first, then string
+	keyField    string
+	message     string
+	details     map[string]any
+	loc         *locationInfo
+	mutex       sync.Mutex
+	seen        map[any]map[string]any // the first event seen for each key
+}
+
+var orderings struct {
+	mutex sync.Mutex
+	list  []*orderingProperty
+}
+
+func describe_event(name string, details any) map[string]any {
+	return map[string]any{"event": name, "details": details}
+}
+
+// event_key returns the value that correlates events for keyField, when details has one
+func event_key(details any, keyField string) (key any, ok bool) {
+	if keyField == "" {
+		return nil, true
+	}
+	fields, is_map := details.(map[string]any)
+	if !is_map {
+		return nil, false
+	}
+	key, ok = fields[keyField]
+	if ok && key != nil && !reflect.TypeOf(key).Comparable() {
+		key = fmt.Sprintf("%#v", key)
+	}
+	return key, ok
+}
+
+func (p *orderingProperty) observe(name string, details any) {
+	if name != p.first && name != p.then {
+		return
+	}
+	key, ok := event_key(details, p.keyField)
+	if !ok {
+		return
+	}
+
+	p.mutex.Lock()
+	preceding, happened := p.seen[key]
+	if name == p.first {
+		if !happened {
+			// The details are copied, since the caller may reuse them once the event is sent
+			p.seen[key] = describe_event(name, normalize(details))
+		}
+		p.mutex.Unlock()
+		return
+	}
+	p.mutex.Unlock()
+
+	id := makeKey(p.message, p.loc)
+	if assertTracker.mayEmit(id, p.loc, happened) {
+		extra := map[string]any{
+			"first_event": nil,
+			"then_event":  describe_event(name, details),
+		}
+		if happened {
+			extra["first_event"] = preceding
+		}
+		if p.keyField != "" {
+			extra["key"] = key
+		}
+		all_details := add_extra_details(p.details, extra)
+		assertImpl(happened, p.message, all_details, p.loc, wasHit, mustBeHit, universalTest, alwaysDisplay, id)
+	}
+}
+
+func observeOrderings(name string, details any) {
+	orderings.mutex.Lock()
+	list := orderings.list
+	orderings.mutex.Unlock()
+	for _, p := range list {
+		p.observe(name, details)
+	}
+}
+
+func resetOrderings() {
+	orderings.mutex.Lock()
+	list := orderings.list
+	orderings.mutex.Unlock()
+	for _, p := range list {
+		p.mutex.Lock()
+		p.seen = map[any]map[string]any{}
+		p.mutex.Unlock()
+	}
+}
+
+// AlwaysHappensBefore declares that every event named then, sent with lifecycle.SendEvent, must be preceded by an event named first. For example, to assert that a value is only read after its write was acknowledged:
+//
+//	assert.AlwaysHappensBefore("write_acked", "read_observed", "key", "reads follow acknowledged writes", nil)
+//	...
+//	lifecycle.SendEvent("write_acked", map[string]any{"key": k})
+//	...
+//	lifecycle.SendEvent("read_observed", map[string]any{"key": k})
+//
+// When keyField is not empty, events are correlated by the value of that key in their details, which must be a map[string]any: a then event must be preceded by a first event with the same value. Events without that key are ignored. When keyField is empty, any earlier first event will do.
+//
+// Each then event is evaluated as it is sent, and reported as Always(first event seen, message, details). Information about the events will automatically be added to the details parameter, with keys first_event, then_event and key. When no first event preceded it, first_event is null.
+//
+// Ordering is only checked within one process: events sent by other processes, including earlier runs of this program, are not seen. The first event for every key is kept for the life of the process, so keys should come from a bounded set. Declaring another property with the same message replaces the previous one.
+func AlwaysHappensBefore(first, then, keyField string, message string, details map[string]any) {
+	loc := callerLocation(offsetAPICaller)
+	id := makeKey(message, loc)
+	assertImpl(false, message, nil, loc, !wasHit, mustBeHit, universalTest, alwaysDisplay, id)
+
+	p := &orderingProperty{
+		first:    first,
+		then:     then,
+		keyField: keyField,
+		message:  message,
+		details:  details,
+		loc:      loc,
+		seen:     map[any]map[string]any{},
+	}
+	orderings.mutex.Lock()
+	defer orderings.mutex.Unlock()
+	// The list is copied, since observeOrderings may be iterating over it
+	list := make([]*orderingProperty, 0, len(orderings.list)+1)
+	for _, existing := range orderings.list {
+		if existing.message != message {
+			list = append(list, existing)
+		}
+	}
+	orderings.list = append(list, p)
+}
@@ -0,0 +1,73 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"testing"
+
+	"github.com/antithesishq/antithesis-sdk-go/lifecycle"
+)
+
+func TestAlwaysHappensBefore(t *testing.T) {
+	out := captureOutput(t)
+
+	AlwaysHappensBefore("write_acked", "read_observed", "key", "reads follow writes", map[string]any{"store": "kv"})
+	lifecycle.SendEvent("write_acked", map[string]any{"key": "a", "value": 1})
+	lifecycle.SendEvent("read_observed", map[string]any{"key": "a", "value": 1})
+	lifecycle.SendEvent("read_observed", map[string]any{"value": 3})
+	lifecycle.SendEvent("read_observed", map[string]any{"key": "b", "value": 2})
+	lifecycle.SendEvent("write_acked", map[string]any{"key": "b", "value": 2})
+	lifecycle.SendEvent("read_observed", map[string]any{"key": "b", "value": 2})
+
+	var hits []assertInfo
+	for _, a := range out.assertions {
+		if a.Hit {
+			hits = append(hits, a)
+		}
+	}
+	if len(hits) != 2 || !hits[0].Condition || hits[1].Condition {
+		t.Fatalf("unexpected assertions %+v", hits)
+	}
+	passed := hits[0].Details
+	if passed["first_event"].(map[string]any)["event"] != "write_acked" || passed["store"] != "kv" {
+		t.Errorf("unexpected details %v", passed)
+	}
+	failed := hits[1].Details
+	then := failed["then_event"].(map[string]any)
+	if failed["key"] != "b" || failed["first_event"] != nil || then["details"].(map[string]any)["value"] != float64(2) {
+		t.Errorf("unexpected details %v", failed)
+	}
+	if s := summaryFor(t, "reads follow writes"); s.PassCount != 2 || s.FailCount != 1 {
+		t.Errorf("unexpected summary %+v", s)
+	}
+}
+
+func TestAlwaysHappensBeforeWithoutKey(t *testing.T) {
+	out := captureOutput(t)
+
+	AlwaysHappensBefore("started", "stopped", "", "stops follow starts", nil)
+	lifecycle.SendEvent("stopped", nil)
+	lifecycle.SendEvent("started", nil)
+	lifecycle.SendEvent("stopped", nil)
+
+	got := conditions(out.assertions[1:])
+	if len(got) != 2 || got[0] || !got[1] {
+		t.Fatalf("emitted conditions %v, want [false true]", got)
+	}
+}
+
+func TestAlwaysHappensBeforeCopiesFirstEvent(t *testing.T) {
+	out := captureOutput(t)
+
+	AlwaysHappensBefore("opened", "closed", "", "closes follow opens", nil)
+	details := map[string]any{"state": "open"}
+	lifecycle.SendEvent("opened", details)
+	details["state"] = "reused"
+	lifecycle.SendEvent("closed", nil)
+
+	a := out.assertions[len(out.assertions)-1]
+	first := a.Details["first_event"].(map[string]any)
+	if !a.Condition || first["details"].(map[string]any)["state"] != "open" {
+		t.Errorf("unexpected details %v", a.Details)
+	}
+}
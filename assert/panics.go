@@ -0,0 +1,57 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// panic_details describes a recovered panic. It must be called while panicking,
+// so that the stack includes the function that panicked.
+func panic_details(recovered any) map[string]any {
+	return map[string]any{
+		"panic":      fmt.Sprintf("%+v", recovered),
+		"panic_type": fmt.Sprintf("%T", recovered),
+		"stack":      string(debug.Stack()),
+	}
+}
+
+// reportPanic reports a recovered panic as Unreachable(message, details)
+func reportPanic(recovered any, message string, details map[string]any, loc *locationInfo) {
+	all_details := add_extra_details(details, panic_details(recovered))
+	id := makeKey(message, loc)
+	assertImpl(false, message, all_details, loc, wasHit, optionallyHit, reachabilityTest, unreachableDisplay, id)
+}
+
+// RecoverAndReport recovers from a panic and reports it as Unreachable(message, details), so that the panic becomes a named test property rather than just a crash. Information about the panic will automatically be added to the details parameter, with keys panic, panic_type and stack. It only has an effect when it is itself the deferred function:
+//
+//	defer assert.RecoverAndReport("worker does not panic", nil)
+//
+// The panic is not propagated any further, so the function that deferred RecoverAndReport returns normally. Use [ReportPanic] to report a panic without recovering from it.
+func RecoverAndReport(message string, details map[string]any) {
+	if r := recover(); r != nil {
+		reportPanic(r, message, details, panicLocation(offsetAPICaller))
+	}
+}
+
+// ReportPanic is [RecoverAndReport] for panics that should still crash the program. After reporting the panic, it panics again with the same value.
+func ReportPanic(message string, details map[string]any) {
+	if r := recover(); r != nil {
+		reportPanic(r, message, details, panicLocation(offsetAPICaller))
+		panic(r)
+	}
+}
+
+// Go runs fn on a new goroutine, and reports any panic in fn as Unreachable(message, details) instead of crashing the program, as with [RecoverAndReport].
+func Go(message string, fn func(), details map[string]any) {
+	loc := callerLocation(offsetAPICaller)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				reportPanic(r, message, details, loc)
+			}
+		}()
+		fn()
+	}()
+}
@@ -0,0 +1,70 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/antithesishq/antithesis-sdk-go/internal"
+)
+
+func panicsWith(value any) {
+	defer RecoverAndReport("recovered panic", map[string]any{"worker": 1})
+	panic(value)
+}
+
+func TestRecoverAndReport(t *testing.T) {
+	out := captureOutput(t)
+
+	panicsWith(errors.New("boom"))
+
+	if len(out.assertions) != 1 {
+		t.Fatalf("emitted %d assertions, want 1", len(out.assertions))
+	}
+	a := out.assertions[0]
+	if a.DisplayType != unreachableDisplay || a.Condition || a.Details["worker"] != float64(1) {
+		t.Errorf("unexpected assertion %+v", a)
+	}
+	if a.Details["panic"] != "boom" || a.Details["panic_type"] != "*errors.errorString" {
+		t.Errorf("unexpected panic details %v", a.Details)
+	}
+	if stack, _ := a.Details["stack"].(string); !strings.Contains(stack, "panicsWith") {
+		t.Errorf("stack does not include the panicking function:\n%s", stack)
+	}
+	if a.Location.Funcname != "panicsWith" {
+		t.Errorf("reported in %s", a.Location.Funcname)
+	}
+}
+
+func TestReportPanic(t *testing.T) {
+	out := captureOutput(t)
+
+	defer func() {
+		if r := recover(); r != "again" {
+			t.Errorf("recovered %v, want the original panic", r)
+		}
+		if len(out.assertions) != 1 || out.assertions[0].Details["panic"] != "again" {
+			t.Errorf("unexpected assertions %+v", out.assertions)
+		}
+	}()
+	func() {
+		defer ReportPanic("reported panic", nil)
+		panic("again")
+	}()
+}
+
+func TestGo(t *testing.T) {
+	internal.ResetTrackers()
+	c := make(assertionChannel, 1)
+	internal.SetOutputHandler(c)
+	t.Cleanup(func() { internal.SetOutputHandler(nil) })
+
+	Go("goroutine panic", func() { panic("in goroutine") }, nil)
+
+	a := receiveAssertion(t, c)
+	if a.Message != "goroutine panic" || a.Details["panic"] != "in goroutine" || a.Location.Funcname != "TestGo" {
+		t.Errorf("unexpected assertion %+v", a)
+	}
+}
@@ -0,0 +1,56 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"testing"
+)
+
+func TestRangeOperands(t *testing.T) {
+	for _, tc := range []struct {
+		value, lo, hi int
+		left, right   int
+	}{
+		{value: 2, lo: 0, hi: 10, left: 2, right: 0},
+		{value: 8, lo: 0, hi: 10, left: 10, right: 8},
+		{value: -5, lo: 0, hi: 10, left: -5, right: 0},
+		{value: 15, lo: 0, hi: 10, left: 10, right: 15},
+	} {
+		left, right := range_operands(tc.value, tc.lo, tc.hi)
+		if left != tc.left || right != tc.right {
+			t.Errorf("range_operands(%d, %d, %d) = %d, %d, want %d, %d",
+				tc.value, tc.lo, tc.hi, left, right, tc.left, tc.right)
+		}
+	}
+
+	left, right := range_operands(0.9, 0.0, 1.0)
+	if left != 1.0 || right != 0.9 {
+		t.Errorf("range_operands(0.9, 0, 1) = %v, %v, want 1, 0.9", left, right)
+	}
+}
+
+func TestAlwaysInRange(t *testing.T) {
+	out := captureOutput(t)
+
+	// Guidance is only sent when value gets closer to either bound
+	for _, v := range []int{5, 3, 4, 8, 9, 11} {
+		AlwaysInRange(v, 0, 10, "range always", nil)
+	}
+
+	if len(out.assertions) != 2 || out.assertions[1].Details["value"] != float64(11) {
+		t.Fatalf("unexpected assertions %+v", out.assertions)
+	}
+	var sent []any
+	for _, g := range out.guidance {
+		sent = append(sent, g.Data.(map[string]any)["left"])
+	}
+	want := []any{float64(5), float64(3), float64(10), float64(10), float64(10)}
+	if len(sent) != len(want) {
+		t.Fatalf("sent guidance with left operands %v, want %v", sent, want)
+	}
+	for i := range want {
+		if sent[i] != want[i] {
+			t.Fatalf("sent guidance with left operands %v, want %v", sent, want)
+		}
+	}
+}
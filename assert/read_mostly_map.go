@@ -0,0 +1,90 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// readMostlyMap is a map that can be read without locking or allocating,
+// once the key being looked up has been present for a while. It is used
+// for the trackers consulted on every assertion, whose keys are added
+// once and then read many times.
+//
+// Reads consult an immutable snapshot. Writes go to a locked map, which
+// is republished as the snapshot once enough reads have missed the
+// snapshot to pay for copying it (the same strategy as sync.Map).
+type readMostlyMap[K comparable, V any] struct {
+	read   atomic.Pointer[map[K]V]
+	mutex  sync.Mutex
+	dirty  map[K]V // all entries, guarded by mutex
+	misses int
+}
+
+func (m *readMostlyMap[K, V]) load(key K) (value V, ok bool) {
+	if read := m.read.Load(); read != nil {
+		if value, ok = (*read)[key]; ok {
+			return value, ok
+		}
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	value, ok = m.dirty[key]
+	if ok {
+		m.missLocked()
+	}
+	return value, ok
+}
+
+// loadOrCreate returns the value for key, calling create to make it if key is not present
+func (m *readMostlyMap[K, V]) loadOrCreate(key K, create func() V) V {
+	if value, ok := m.load(key); ok {
+		return value
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if value, ok := m.dirty[key]; ok {
+		return value
+	}
+	if m.dirty == nil {
+		m.dirty = make(map[K]V)
+	}
+	value := create()
+	m.dirty[key] = value
+	m.missLocked()
+	return value
+}
+
+// rangeLocked calls f for every entry, with the map locked.
+// f may delete the entry it is called with, by returning false.
+func (m *readMostlyMap[K, V]) rangeLocked(f func(key K, value V) (keep bool)) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	deleted := false
+	for key, value := range m.dirty {
+		if !f(key, value) {
+			delete(m.dirty, key)
+			deleted = true
+		}
+	}
+	if deleted {
+		m.publishLocked()
+	}
+}
+
+func (m *readMostlyMap[K, V]) missLocked() {
+	m.misses++
+	if m.misses >= len(m.dirty) {
+		m.publishLocked()
+	}
+}
+
+func (m *readMostlyMap[K, V]) publishLocked() {
+	read := make(map[K]V, len(m.dirty))
+	for key, value := range m.dirty {
+		read[key] = value
+	}
+	m.read.Store(&read)
+	m.misses = 0
+}
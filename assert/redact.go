@@ -0,0 +1,89 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+const redactedMarker = "[redacted]"
+
+// redaction is the set of patterns applied to details and events. It is replaced (never
+// modified) when the patterns change, so that it can be used without holding a lock.
+type redaction struct {
+	keys   []string // lower case path.Match patterns
+	values []*regexp.Regexp
+}
+
+var (
+	current_redaction atomic.Pointer[redaction]
+	redaction_mutex   sync.Mutex
+)
+
+func (r *redaction) redactsKey(key string) bool {
+	if r == nil {
+		return false
+	}
+	key = strings.ToLower(key)
+	for _, pattern := range r.keys {
+		if matched, _ := path.Match(pattern, key); matched {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *redaction) redactValue(s string) string {
+	if r == nil {
+		return s
+	}
+	for _, pattern := range r.values {
+		s = pattern.ReplaceAllLiteralString(s, redactedMarker)
+	}
+	return s
+}
+
+// update replaces the current redaction with a modified copy of it
+func update_redaction(modify func(r *redaction)) {
+	redaction_mutex.Lock()
+	defer redaction_mutex.Unlock()
+	var r redaction
+	if prev := current_redaction.Load(); prev != nil {
+		r = *prev
+	}
+	modify(&r)
+	if len(r.keys) == 0 && len(r.values) == 0 {
+		current_redaction.Store(nil)
+	} else {
+		current_redaction.Store(&r)
+	}
+	send_redaction(current_redaction.Load())
+}
+
+// RedactKeys redacts the values of keys that match any of patterns, wherever they appear in the details of assertions and the details of lifecycle events: in maps, in struct fields, and in slog groups. The value is reported as "[redacted]" before it reaches any output handler, including the file named by ANTITHESIS_SDK_LOCAL_OUTPUT. Patterns use the syntax of [path.Match] and are matched against the whole key, ignoring case, so "*token*" matches both "token" and "AuthToken".
+//
+// Each call replaces the key patterns set by the previous call, and calling RedactKeys with no patterns stops redacting keys. An error is returned, and the patterns are left unchanged, if any pattern is malformed.
+func RedactKeys(patterns ...string) error {
+	keys := make([]string, 0, len(patterns))
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(pattern)
+		if _, err := path.Match(pattern, ""); err != nil {
+			return err
+		}
+		keys = append(keys, pattern)
+	}
+	update_redaction(func(r *redaction) { r.keys = keys })
+	return nil
+}
+
+// RedactValues replaces every part of a string that matches any of patterns with "[redacted]", wherever the string appears in the details of assertions and the details of lifecycle events. This includes the messages of errors and the output of String methods. Use it for secrets that can be recognized by their format, such as bearer tokens or email addresses.
+//
+// Each call replaces the value patterns set by the previous call, and calling RedactValues with no patterns stops redacting values.
+func RedactValues(patterns ...*regexp.Regexp) {
+	values := append([]*regexp.Regexp(nil), patterns...)
+	update_redaction(func(r *redaction) { r.values = values })
+}
@@ -0,0 +1,124 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/antithesishq/antithesis-sdk-go/lifecycle"
+)
+
+func redactForTest(t *testing.T, keys []string, values ...*regexp.Regexp) {
+	t.Helper()
+	if err := RedactKeys(keys...); err != nil {
+		t.Fatal(err)
+	}
+	RedactValues(values...)
+	t.Cleanup(func() {
+		RedactKeys()
+		RedactValues()
+	})
+}
+
+type credentials struct {
+	User     string
+	Password string
+}
+
+type apiKey string
+
+func (apiKey) AntithesisRedact() bool { return true }
+
+type commitHash string
+
+func (commitHash) AntithesisRedact() bool { return false }
+
+type marshalsToken struct{}
+
+func (marshalsToken) MarshalJSON() ([]byte, error) {
+	return []byte(`{"token":"abc","kind":"session"}`), nil
+}
+
+type marshalsID struct{}
+
+func (marshalsID) MarshalJSON() ([]byte, error) {
+	return []byte(`{"id":9007199254740993,"token":"abc"}`), nil
+}
+
+func TestRedactKeys(t *testing.T) {
+	redactForTest(t, []string{"*token*", "password"})
+
+	checkNormalized(t, map[string]any{"AuthToken": "abc", "user": "alice"}, `{"AuthToken":"[redacted]","user":"alice"}`)
+	checkNormalized(t, []any{credentials{"alice", "hunter2"}}, `[{"Password":"[redacted]","User":"alice"}]`)
+	checkNormalized(t, map[string]any{"nested": map[string]any{"token": map[string]any{"a": 1}}}, `{"nested":{"token":"[redacted]"}}`)
+	checkNormalized(t, marshalsToken{}, `{"kind":"session","token":"[redacted]"}`)
+
+	// Numbers in marshaled values keep their precision
+	checkNormalized(t, marshalsID{}, `{"id":9007199254740993,"token":"[redacted]"}`)
+
+	group := slog.GroupValue(slog.String("password", "hunter2"), slog.Int("attempts", 3))
+	checkNormalized(t, map[string]any{"login": group}, `{"login":{"attempts":3,"password":"[redacted]"}}`)
+
+	RedactKeys()
+	checkNormalized(t, map[string]any{"token": "abc"}, `{"token":"abc"}`)
+}
+
+func TestRedactValues(t *testing.T) {
+	redactForTest(t, nil, regexp.MustCompile(`Bearer [A-Za-z0-9]+`))
+
+	err := errors.New("request with Bearer abc123 rejected")
+	checkNormalized(t, map[string]any{"err": err, "header": "Authorization: Bearer xyz"},
+		`{"err":"request with [redacted] rejected","header":"Authorization: [redacted]"}`)
+}
+
+func TestRedactable(t *testing.T) {
+	// Redactable types decide for themselves, whatever the patterns
+	checkNormalized(t, map[string]any{"key": apiKey("abc")}, `{"key":"[redacted]"}`)
+
+	redactForTest(t, []string{"*hash*"}, regexp.MustCompile(`[0-9a-f]{8}`))
+	checkNormalized(t, map[string]any{"head": commitHash("deadbeef"), "other": "deadbeef"}, `{"head":"deadbeef","other":"[redacted]"}`)
+	checkNormalized(t, map[string]any{"x": map[commitHash]any{"hash": "deadbeef"}}, `{"x":{"hash":"[redacted]"}}`)
+}
+
+func TestRedactKeysBadPattern(t *testing.T) {
+	redactForTest(t, []string{"secret"})
+	if err := RedactKeys("[unterminated"); err == nil {
+		t.Fatal("RedactKeys accepted a malformed pattern")
+	}
+	// The previous patterns still apply
+	checkNormalized(t, map[string]any{"secret": 1}, `{"secret":"[redacted]"}`)
+}
+
+func TestRedactAssertionsAndEvents(t *testing.T) {
+	out := captureOutput(t)
+	redactForTest(t, []string{"password"}, regexp.MustCompile(`sk-[a-z0-9]+`))
+
+	Always(false, "redacted assertion", map[string]any{"password": "hunter2", "key": "sk-abc123"})
+	lifecycle.SendEvent("redacted event", map[string]any{"password": "hunter2", "note": "uses sk-abc123"})
+
+	if len(out.assertions) != 1 {
+		t.Fatalf("emitted %d assertions, want 1", len(out.assertions))
+	}
+	if d := out.assertions[0].Details; d["password"] != redactedMarker || d["key"] != redactedMarker {
+		t.Errorf("unexpected details %v", d)
+	}
+
+	var event map[string]map[string]any
+	last := out.messages[len(out.messages)-1]
+	if err := json.Unmarshal([]byte(last), &event); err != nil {
+		t.Fatal(err)
+	}
+	if d := event["redacted event"]; d["password"] != redactedMarker || d["note"] != "uses "+redactedMarker {
+		t.Errorf("unexpected event %s", last)
+	}
+	for _, message := range out.messages {
+		if strings.Contains(message, "hunter2") || strings.Contains(message, "sk-abc123") {
+			t.Errorf("secret emitted in %s", message)
+		}
+	}
+}
@@ -2,6 +2,11 @@
 
 package assert
 
+import (
+	"context"
+	"reflect"
+)
+
 // A type for writing raw assertions.
 // guidanceFnType allows the assertion to provide guidance to
 // the Antithesis platform when testing in Antithesis.
@@ -140,12 +145,25 @@ func behavior_to_guidance(behavior string) guidanceFnType {
 
 func numericGuidanceImpl[T Number](left, right T, message, id string, loc *locationInfo, guidanceFn guidanceFnType, hit bool) {
 	tI := numeric_guidance_tracker.getTrackerEntry(id, gapTypeForOperand(left), uses_maximize(guidanceFn))
+
+	// Guidance that is no better than what was already sent is dropped
+	// before anything is allocated
+	gap := numericGapFor(left, right)
+	if hit && !tI.improves(gap) {
+		return
+	}
 	gI := build_numeric_guidance(guidanceFn, message, left, right, loc, id, hit)
-	send_value_if_needed(tI, gI)
+	send_value_if_needed(tI, gI, gap)
 }
 
 func booleanGuidanceImpl(named_bools []NamedBool, message, id string, loc *locationInfo, guidanceFn guidanceFnType, hit bool) {
-	tI := boolean_guidance_tracker.getTrackerEntry(id)
+	tI := boolean_guidance_tracker.getTrackerEntry(id, uses_maximize(guidanceFn))
+
+	// Combinations that are no better than one already sent are dropped
+	// before anything is allocated
+	if hit && tI.covered(named_bools) {
+		return
+	}
 	bgI := build_boolean_guidance(guidanceFn, message, named_bools, loc, id, hit)
 	tI.send_value(bgI)
 }
@@ -178,126 +196,255 @@ func BooleanGuidanceRaw(
 	booleanGuidanceImpl(named_bools, message, id, loc, guidanceFn, hit)
 }
 
+// add_numeric_details defers copying details until the assertion is emitted
 func add_numeric_details[T Number](details map[string]any, left, right T) map[string]any {
-	// ----------------------------------------------------
-	// Can not use maps.Clone() until go 1.21.0 or above
-	// enhancedDetails := maps.Clone(details)
-	// ----------------------------------------------------
-	enhancedDetails := map[string]any{}
-	for k, v := range details {
-		enhancedDetails[k] = v
-	}
-	enhancedDetails["left"] = left
-	enhancedDetails["right"] = right
-	return enhancedDetails
+	return map[string]any{lazyDetailsKey: lazyDetails(func() map[string]any {
+		// ----------------------------------------------------
+		// Can not use maps.Clone() until go 1.21.0 or above
+		// enhancedDetails := maps.Clone(details)
+		// ----------------------------------------------------
+		enhancedDetails := map[string]any{}
+		for k, v := range resolveDetails(details) {
+			enhancedDetails[k] = v
+		}
+		enhancedDetails["left"] = left
+		enhancedDetails["right"] = right
+		return enhancedDetails
+	})}
 }
 
+// add_boolean_details defers copying details until the assertion is emitted
 func add_boolean_details(details map[string]any, named_bools []NamedBool) map[string]any {
-	// ----------------------------------------------------
-	// Can not use maps.Clone() until go 1.21.0 or above
-	// enhancedDetails := maps.Clone(details)
-	// ----------------------------------------------------
-	enhancedDetails := map[string]any{}
-	for k, v := range details {
-		enhancedDetails[k] = v
-	}
-	for _, named_bool := range named_bools {
-		enhancedDetails[named_bool.First] = named_bool.Second
-	}
-	return enhancedDetails
+	return map[string]any{lazyDetailsKey: lazyDetails(func() map[string]any {
+		// ----------------------------------------------------
+		// Can not use maps.Clone() until go 1.21.0 or above
+		// enhancedDetails := maps.Clone(details)
+		// ----------------------------------------------------
+		enhancedDetails := map[string]any{}
+		for k, v := range resolveDetails(details) {
+			enhancedDetails[k] = v
+		}
+		for _, named_bool := range named_bools {
+			enhancedDetails[named_bool.First] = named_bool.Second
+		}
+		return enhancedDetails
+	})}
+}
+
+// add_extra_details defers copying details until the assertion is emitted
+func add_extra_details(details map[string]any, extra map[string]any) map[string]any {
+	return map[string]any{lazyDetailsKey: lazyDetails(func() map[string]any {
+		enhancedDetails := map[string]any{}
+		for k, v := range resolveDetails(details) {
+			enhancedDetails[k] = v
+		}
+		for k, v := range extra {
+			enhancedDetails[k] = v
+		}
+		return enhancedDetails
+	})}
 }
 
 // Equivalent to asserting Always(left > right, message, details). Information about left and right will automatically be added to the details parameter, with keys left and right. If you use this function for assertions that compare numeric quantities, you may help Antithesis find more bugs.
 func AlwaysGreaterThan[T Number](left, right T, message string, details map[string]any) {
-	loc := newLocationInfo(offsetAPICaller)
+	alwaysGreaterThanImpl(context.Background(), callerLocation(offsetAPICaller), left, right, message, details)
+}
+
+func alwaysGreaterThanImpl[T Number](ctx context.Context, loc *locationInfo, left, right T, message string, details map[string]any) {
 	id := makeKey(message, loc)
 	condition := left > right
-	all_details := add_numeric_details(details, left, right)
-	assertImpl(condition, message, all_details, loc, wasHit, mustBeHit, universalTest, alwaysDisplay, id)
+	if assertTracker.mayEmit(id, loc, condition) {
+		all_details := add_numeric_details(with_context_details(ctx, details), left, right)
+		assertImpl(condition, message, all_details, loc, wasHit, mustBeHit, universalTest, alwaysDisplay, id)
+	}
 
 	numericGuidanceImpl(left, right, message, id, loc, guidanceFnMinimize, wasHit)
 }
 
 // Equivalent to asserting Always(left >= right, message, details). Information about left and right will automatically be added to the details parameter, with keys left and right. If you use this function for assertions that compare numeric quantities, you may help Antithesis find more bugs.
 func AlwaysGreaterThanOrEqualTo[T Number](left, right T, message string, details map[string]any) {
-	loc := newLocationInfo(offsetAPICaller)
+	alwaysGreaterThanOrEqualToImpl(context.Background(), callerLocation(offsetAPICaller), left, right, message, details)
+}
+
+func alwaysGreaterThanOrEqualToImpl[T Number](ctx context.Context, loc *locationInfo, left, right T, message string, details map[string]any) {
 	id := makeKey(message, loc)
 	condition := left >= right
-	all_details := add_numeric_details(details, left, right)
-	assertImpl(condition, message, all_details, loc, wasHit, mustBeHit, universalTest, alwaysDisplay, id)
+	if assertTracker.mayEmit(id, loc, condition) {
+		all_details := add_numeric_details(with_context_details(ctx, details), left, right)
+		assertImpl(condition, message, all_details, loc, wasHit, mustBeHit, universalTest, alwaysDisplay, id)
+	}
 
 	numericGuidanceImpl(left, right, message, id, loc, guidanceFnMinimize, wasHit)
 }
 
 // Equivalent to asserting Sometimes(T left > T right, message, details). Information about left and right will automatically be added to the details parameter, with keys left and right. If you use this function for assertions that compare numeric quantities, you may help Antithesis find more bugs.
 func SometimesGreaterThan[T Number](left, right T, message string, details map[string]any) {
-	loc := newLocationInfo(offsetAPICaller)
+	sometimesGreaterThanImpl(context.Background(), callerLocation(offsetAPICaller), left, right, message, details)
+}
+
+func sometimesGreaterThanImpl[T Number](ctx context.Context, loc *locationInfo, left, right T, message string, details map[string]any) {
 	id := makeKey(message, loc)
 	condition := left > right
-	all_details := add_numeric_details(details, left, right)
-	assertImpl(condition, message, all_details, loc, wasHit, mustBeHit, existentialTest, sometimesDisplay, id)
+	if assertTracker.mayEmit(id, loc, condition) {
+		all_details := add_numeric_details(with_context_details(ctx, details), left, right)
+		assertImpl(condition, message, all_details, loc, wasHit, mustBeHit, existentialTest, sometimesDisplay, id)
+	}
 
 	numericGuidanceImpl(left, right, message, id, loc, guidanceFnMaximize, wasHit)
 }
 
 // Equivalent to asserting Sometimes(T left >= T right, message, details). Information about left and right will automatically be added to the details parameter, with keys left and right. If you use this function for assertions that compare numeric quantities, you may help Antithesis find more bugs.
 func SometimesGreaterThanOrEqualTo[T Number](left, right T, message string, details map[string]any) {
-	loc := newLocationInfo(offsetAPICaller)
+	sometimesGreaterThanOrEqualToImpl(context.Background(), callerLocation(offsetAPICaller), left, right, message, details)
+}
+
+func sometimesGreaterThanOrEqualToImpl[T Number](ctx context.Context, loc *locationInfo, left, right T, message string, details map[string]any) {
 	id := makeKey(message, loc)
 	condition := left >= right
-	all_details := add_numeric_details(details, left, right)
-	assertImpl(condition, message, all_details, loc, wasHit, mustBeHit, existentialTest, sometimesDisplay, id)
+	if assertTracker.mayEmit(id, loc, condition) {
+		all_details := add_numeric_details(with_context_details(ctx, details), left, right)
+		assertImpl(condition, message, all_details, loc, wasHit, mustBeHit, existentialTest, sometimesDisplay, id)
+	}
 
 	numericGuidanceImpl(left, right, message, id, loc, guidanceFnMaximize, wasHit)
 }
 
 // Equivalent to asserting Always(left < right, message, details). Information about left and right will automatically be added to the details parameter, with keys left and right. If you use this function for assertions that compare numeric quantities, you may help Antithesis find more bugs.
 func AlwaysLessThan[T Number](left, right T, message string, details map[string]any) {
-	loc := newLocationInfo(offsetAPICaller)
+	alwaysLessThanImpl(context.Background(), callerLocation(offsetAPICaller), left, right, message, details)
+}
+
+func alwaysLessThanImpl[T Number](ctx context.Context, loc *locationInfo, left, right T, message string, details map[string]any) {
 	id := makeKey(message, loc)
 	condition := left < right
-	all_details := add_numeric_details(details, left, right)
-	assertImpl(condition, message, all_details, loc, wasHit, mustBeHit, universalTest, alwaysDisplay, id)
+	if assertTracker.mayEmit(id, loc, condition) {
+		all_details := add_numeric_details(with_context_details(ctx, details), left, right)
+		assertImpl(condition, message, all_details, loc, wasHit, mustBeHit, universalTest, alwaysDisplay, id)
+	}
 
 	numericGuidanceImpl(left, right, message, id, loc, guidanceFnMaximize, wasHit)
 }
 
 // Equivalent to asserting Always(left <= right, message, details). Information about left and right will automatically be added to the details parameter, with keys left and right. If you use this function for assertions that compare numeric quantities, you may help Antithesis find more bugs.
 func AlwaysLessThanOrEqualTo[T Number](left, right T, message string, details map[string]any) {
-	loc := newLocationInfo(offsetAPICaller)
+	alwaysLessThanOrEqualToImpl(context.Background(), callerLocation(offsetAPICaller), left, right, message, details)
+}
+
+func alwaysLessThanOrEqualToImpl[T Number](ctx context.Context, loc *locationInfo, left, right T, message string, details map[string]any) {
 	id := makeKey(message, loc)
 	condition := left <= right
-	all_details := add_numeric_details(details, left, right)
-	assertImpl(condition, message, all_details, loc, wasHit, mustBeHit, universalTest, alwaysDisplay, id)
+	if assertTracker.mayEmit(id, loc, condition) {
+		all_details := add_numeric_details(with_context_details(ctx, details), left, right)
+		assertImpl(condition, message, all_details, loc, wasHit, mustBeHit, universalTest, alwaysDisplay, id)
+	}
 
 	numericGuidanceImpl(left, right, message, id, loc, guidanceFnMaximize, wasHit)
 }
 
 // Equivalent to asserting Sometimes(T left < T right, message, details). Information about left and right will automatically be added to the details parameter, with keys left and right. If you use this function for assertions that compare numeric quantities, you may help Antithesis find more bugs.
 func SometimesLessThan[T Number](left, right T, message string, details map[string]any) {
-	loc := newLocationInfo(offsetAPICaller)
+	sometimesLessThanImpl(context.Background(), callerLocation(offsetAPICaller), left, right, message, details)
+}
+
+func sometimesLessThanImpl[T Number](ctx context.Context, loc *locationInfo, left, right T, message string, details map[string]any) {
 	id := makeKey(message, loc)
 	condition := left < right
-	all_details := add_numeric_details(details, left, right)
-	assertImpl(condition, message, all_details, loc, wasHit, mustBeHit, existentialTest, sometimesDisplay, id)
+	if assertTracker.mayEmit(id, loc, condition) {
+		all_details := add_numeric_details(with_context_details(ctx, details), left, right)
+		assertImpl(condition, message, all_details, loc, wasHit, mustBeHit, existentialTest, sometimesDisplay, id)
+	}
 
 	numericGuidanceImpl(left, right, message, id, loc, guidanceFnMinimize, wasHit)
 }
 
 // Equivalent to asserting Sometimes(T left <= T right, message, details). Information about left and right will automatically be added to the details parameter, with keys left and right. If you use this function for assertions that compare numeric quantities, you may help Antithesis find more bugs.
 func SometimesLessThanOrEqualTo[T Number](left, right T, message string, details map[string]any) {
-	loc := newLocationInfo(offsetAPICaller)
+	sometimesLessThanOrEqualToImpl(context.Background(), callerLocation(offsetAPICaller), left, right, message, details)
+}
+
+func sometimesLessThanOrEqualToImpl[T Number](ctx context.Context, loc *locationInfo, left, right T, message string, details map[string]any) {
 	id := makeKey(message, loc)
 	condition := left <= right
-	all_details := add_numeric_details(details, left, right)
-	assertImpl(condition, message, all_details, loc, wasHit, mustBeHit, existentialTest, sometimesDisplay, id)
+	if assertTracker.mayEmit(id, loc, condition) {
+		all_details := add_numeric_details(with_context_details(ctx, details), left, right)
+		assertImpl(condition, message, all_details, loc, wasHit, mustBeHit, existentialTest, sometimesDisplay, id)
+	}
 
 	numericGuidanceImpl(left, right, message, id, loc, guidanceFnMinimize, wasHit)
 }
 
+// range_operands chooses the bound of [lo, hi] nearest to being violated by value,
+// and returns it as operands whose gap (left - right) is the distance to that bound.
+// The gap is negative when value is outside of that bound.
+func range_operands[T Number](value, lo, hi T) (left, right T) {
+	lower := numericGapFor(value, lo)
+	upper := numericGapFor(hi, value)
+	upper_is_nearer := false
+	if gapTypeForOperand(value) == integerGapType {
+		upper_is_nearer = is_less_than(upper.gap, lower.gap)
+	} else {
+		upper_is_nearer = is_less_than(upper.float_gap, lower.float_gap)
+	}
+	if upper_is_nearer {
+		return hi, value
+	}
+	return value, lo
+}
+
+// add_range_details defers copying details until the assertion is emitted
+func add_range_details[T Number](details map[string]any, value, lo, hi T) map[string]any {
+	return map[string]any{lazyDetailsKey: lazyDetails(func() map[string]any {
+		enhancedDetails := map[string]any{}
+		for k, v := range resolveDetails(details) {
+			enhancedDetails[k] = v
+		}
+		enhancedDetails["value"] = value
+		enhancedDetails["lo"] = lo
+		enhancedDetails["hi"] = hi
+		return enhancedDetails
+	})}
+}
+
+// Equivalent to asserting Always(lo <= value && value <= hi, message, details). Information about value, lo and hi will automatically be added to the details parameter, with keys value, lo and hi. Antithesis is guided towards values beyond whichever bound value is nearest to, which may help it find more bugs.
+func AlwaysInRange[T Number](value, lo, hi T, message string, details map[string]any) {
+	alwaysInRangeImpl(context.Background(), callerLocation(offsetAPICaller), value, lo, hi, message, details)
+}
+
+func alwaysInRangeImpl[T Number](ctx context.Context, loc *locationInfo, value, lo, hi T, message string, details map[string]any) {
+	id := makeKey(message, loc)
+	condition := lo <= value && value <= hi
+	if assertTracker.mayEmit(id, loc, condition) {
+		all_details := add_range_details(with_context_details(ctx, details), value, lo, hi)
+		assertImpl(condition, message, all_details, loc, wasHit, mustBeHit, universalTest, alwaysDisplay, id)
+	}
+
+	left, right := range_operands(value, lo, hi)
+	numericGuidanceImpl(left, right, message, id, loc, guidanceFnMinimize, wasHit)
+}
+
+// Equivalent to asserting Sometimes(lo <= value && value <= hi, message, details). Information about value, lo and hi will automatically be added to the details parameter, with keys value, lo and hi. Antithesis is guided towards values within the range, which may help it find more bugs.
+func SometimesInRange[T Number](value, lo, hi T, message string, details map[string]any) {
+	sometimesInRangeImpl(context.Background(), callerLocation(offsetAPICaller), value, lo, hi, message, details)
+}
+
+func sometimesInRangeImpl[T Number](ctx context.Context, loc *locationInfo, value, lo, hi T, message string, details map[string]any) {
+	id := makeKey(message, loc)
+	condition := lo <= value && value <= hi
+	if assertTracker.mayEmit(id, loc, condition) {
+		all_details := add_range_details(with_context_details(ctx, details), value, lo, hi)
+		assertImpl(condition, message, all_details, loc, wasHit, mustBeHit, existentialTest, sometimesDisplay, id)
+	}
+
+	left, right := range_operands(value, lo, hi)
+	numericGuidanceImpl(left, right, message, id, loc, guidanceFnMaximize, wasHit)
+}
+
 // Asserts that every time this is called, at least one bool in named_bools is true. Equivalent to Always(named_bools[0].second || named_bools[1].second || ..., message, details). If you use this for assertions about the behavior of booleans, you may help Antithesis find more bugs. Information about named_bools will automatically be added to the details parameter, and the keys will be the names of the bools.
 func AlwaysSome(named_bools []NamedBool, message string, details map[string]any) {
-	loc := newLocationInfo(offsetAPICaller)
+	alwaysSomeImpl(context.Background(), callerLocation(offsetAPICaller), named_bools, message, details)
+}
+
+func alwaysSomeImpl(ctx context.Context, loc *locationInfo, named_bools []NamedBool, message string, details map[string]any) {
 	id := makeKey(message, loc)
 	disjunction := false
 	for _, named_bool := range named_bools {
@@ -306,15 +453,20 @@ func AlwaysSome(named_bools []NamedBool, message string, details map[string]any)
 			break
 		}
 	}
-	all_details := add_boolean_details(details, named_bools)
-	assertImpl(disjunction, message, all_details, loc, wasHit, mustBeHit, universalTest, alwaysDisplay, id)
+	if assertTracker.mayEmit(id, loc, disjunction) {
+		all_details := add_boolean_details(with_context_details(ctx, details), named_bools)
+		assertImpl(disjunction, message, all_details, loc, wasHit, mustBeHit, universalTest, alwaysDisplay, id)
+	}
 
 	booleanGuidanceImpl(named_bools, message, id, loc, guidanceFnWantNone, wasHit)
 }
 
 // Asserts that at least one time this is called, every bool in named_bools is true. Equivalent to Sometimes(named_bools[0].second && named_bools[1].second && ..., message, details). If you use this for assertions about the behavior of booleans, you may help Antithesis find more bugs. Information about named_bools will automatically be added to the details parameter, and the keys will be the names of the bools.
 func SometimesAll(named_bools []NamedBool, message string, details map[string]any) {
-	loc := newLocationInfo(offsetAPICaller)
+	sometimesAllImpl(context.Background(), callerLocation(offsetAPICaller), named_bools, message, details)
+}
+
+func sometimesAllImpl(ctx context.Context, loc *locationInfo, named_bools []NamedBool, message string, details map[string]any) {
 	id := makeKey(message, loc)
 	conjunction := true
 	for _, named_bool := range named_bools {
@@ -323,8 +475,82 @@ func SometimesAll(named_bools []NamedBool, message string, details map[string]an
 			break
 		}
 	}
-	all_details := add_boolean_details(details, named_bools)
-	assertImpl(conjunction, message, all_details, loc, wasHit, mustBeHit, existentialTest, sometimesDisplay, id)
+	if assertTracker.mayEmit(id, loc, conjunction) {
+		all_details := add_boolean_details(with_context_details(ctx, details), named_bools)
+		assertImpl(conjunction, message, all_details, loc, wasHit, mustBeHit, existentialTest, sometimesDisplay, id)
+	}
 
 	booleanGuidanceImpl(named_bools, message, id, loc, guidanceFnWantAll, wasHit)
 }
+
+// add_equality_details defers copying details and computing the diff until the assertion is emitted
+func add_equality_details[T any](details map[string]any, expected, actual T, equal bool) map[string]any {
+	return map[string]any{lazyDetailsKey: lazyDetails(func() map[string]any {
+		enhancedDetails := map[string]any{}
+		for k, v := range resolveDetails(details) {
+			enhancedDetails[k] = v
+		}
+		enhancedDetails["expected"] = expected
+		enhancedDetails["actual"] = actual
+		if !equal {
+			enhancedDetails["diff"] = diffValues(expected, actual)
+		}
+		return enhancedDetails
+	})}
+}
+
+// Equivalent to asserting Always(expected == actual, message, details). Information about expected and actual will automatically be added to the details parameter, with keys expected and actual, along with a description of their differences under the key diff.
+func AlwaysEqual[T comparable](expected, actual T, message string, details map[string]any) {
+	alwaysEqualImpl(context.Background(), callerLocation(offsetAPICaller), expected, actual, message, details)
+}
+
+func alwaysEqualImpl[T comparable](ctx context.Context, loc *locationInfo, expected, actual T, message string, details map[string]any) {
+	id := makeKey(message, loc)
+	condition := expected == actual
+	if assertTracker.mayEmit(id, loc, condition) {
+		all_details := add_equality_details(with_context_details(ctx, details), expected, actual, condition)
+		assertImpl(condition, message, all_details, loc, wasHit, mustBeHit, universalTest, alwaysDisplay, id)
+	}
+}
+
+// Equivalent to asserting Sometimes(expected == actual, message, details). Information about expected and actual will automatically be added to the details parameter, with keys expected and actual, along with a description of their differences under the key diff.
+func SometimesEqual[T comparable](expected, actual T, message string, details map[string]any) {
+	sometimesEqualImpl(context.Background(), callerLocation(offsetAPICaller), expected, actual, message, details)
+}
+
+func sometimesEqualImpl[T comparable](ctx context.Context, loc *locationInfo, expected, actual T, message string, details map[string]any) {
+	id := makeKey(message, loc)
+	condition := expected == actual
+	if assertTracker.mayEmit(id, loc, condition) {
+		all_details := add_equality_details(with_context_details(ctx, details), expected, actual, condition)
+		assertImpl(condition, message, all_details, loc, wasHit, mustBeHit, existentialTest, sometimesDisplay, id)
+	}
+}
+
+// Equivalent to asserting Always(reflect.DeepEqual(expected, actual), message, details), for comparing slices, maps, structs and pointers to them. Information about expected and actual will automatically be added to the details parameter, with keys expected and actual, along with a description of their differences under the key diff.
+func AlwaysDeepEqual[T any](expected, actual T, message string, details map[string]any) {
+	alwaysDeepEqualImpl(context.Background(), callerLocation(offsetAPICaller), expected, actual, message, details)
+}
+
+func alwaysDeepEqualImpl[T any](ctx context.Context, loc *locationInfo, expected, actual T, message string, details map[string]any) {
+	id := makeKey(message, loc)
+	condition := reflect.DeepEqual(expected, actual)
+	if assertTracker.mayEmit(id, loc, condition) {
+		all_details := add_equality_details(with_context_details(ctx, details), expected, actual, condition)
+		assertImpl(condition, message, all_details, loc, wasHit, mustBeHit, universalTest, alwaysDisplay, id)
+	}
+}
+
+// Equivalent to asserting Sometimes(reflect.DeepEqual(expected, actual), message, details), for comparing slices, maps, structs and pointers to them. Information about expected and actual will automatically be added to the details parameter, with keys expected and actual, along with a description of their differences under the key diff.
+func SometimesDeepEqual[T any](expected, actual T, message string, details map[string]any) {
+	sometimesDeepEqualImpl(context.Background(), callerLocation(offsetAPICaller), expected, actual, message, details)
+}
+
+func sometimesDeepEqualImpl[T any](ctx context.Context, loc *locationInfo, expected, actual T, message string, details map[string]any) {
+	id := makeKey(message, loc)
+	condition := reflect.DeepEqual(expected, actual)
+	if assertTracker.mayEmit(id, loc, condition) {
+		all_details := add_equality_details(with_context_details(ctx, details), expected, actual, condition)
+		assertImpl(condition, message, all_details, loc, wasHit, mustBeHit, existentialTest, sometimesDisplay, id)
+	}
+}
@@ -10,6 +10,29 @@ func AlwaysLessThan[T Number](left, right T, message string, details map[string]
 func AlwaysLessThanOrEqualTo[T Number](left, right T, message string, details map[string]any)       {}
 func SometimesLessThan[T Number](left, right T, message string, details map[string]any)             {}
 func SometimesLessThanOrEqualTo[T Number](left, right T, message string, details map[string]any)    {}
+func AlwaysInRange[T Number](value, lo, hi T, message string, details map[string]any)               {}
+func SometimesInRange[T Number](value, lo, hi T, message string, details map[string]any)            {}
+
+func AlwaysEqual[T comparable](expected, actual T, message string, details map[string]any)    {}
+func SometimesEqual[T comparable](expected, actual T, message string, details map[string]any) {}
+func AlwaysDeepEqual[T any](expected, actual T, message string, details map[string]any)       {}
+func SometimesDeepEqual[T any](expected, actual T, message string, details map[string]any)    {}
+
+func AlwaysNoError(err error, message string, details map[string]any)             {}
+func SometimesError(err error, message string, details map[string]any)            {}
+func SometimesErrorIs(err, target error, message string, details map[string]any)  {}
+func AlwaysErrorAs(err error, target any, message string, details map[string]any) {}
+
+func SometimesEach(value any, message string, details map[string]any)                      {}
+func SometimesEachOf[T any](value T, expected []T, message string, details map[string]any) {}
+
+func SometimesAtLeast(condition bool, n int, message string, details map[string]any)            {}
+func AlwaysFractionBelow(condition bool, ratio float64, message string, details map[string]any) {}
+
+func AlwaysMonotonic[T Number](key string, value T, message string, details map[string]any)         {}
+func AlwaysStrictlyMonotonic[T Number](key string, value T, message string, details map[string]any) {}
+
+func AlwaysUnique[T comparable](namespace string, id T, message string, details map[string]any) {}
 
 func AlwaysSome(named_bool []NamedBool, message string, details map[string]any)   {}
 func SometimesAll(named_bool []NamedBool, message string, details map[string]any) {}
@@ -32,3 +55,14 @@ func BooleanGuidanceRaw(
 	hit bool,
 ) {
 }
+
+func Explore(message string, state any) {}
+
+func ExploreGuidanceRaw(
+	state any,
+	message, id string,
+	classname, funcname, filename string,
+	line int,
+	hit bool,
+) {
+}
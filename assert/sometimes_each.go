@@ -0,0 +1,104 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+)
+
+// eachRegistrations records the messages of SometimesEachOf assertions whose expected values are being, or have been, registered
+var eachRegistrations readMostlyMap[string, *atomic.Bool]
+
+// eachKey identifies the test property of a value
+type eachKey struct {
+	message string
+	value   any
+}
+
+// eachMessages caches the messages of the test properties of values of basic kinds, so that they are only formatted once
+var eachMessages readMostlyMap[eachKey, string]
+
+func each_message(message string, value any) string {
+	if !each_cacheable(value) {
+		return fmt.Sprintf("%s: %v", message, value)
+	}
+	key := eachKey{message, value}
+	if each, ok := eachMessages.load(key); ok {
+		return each
+	}
+	return eachMessages.loadOrCreate(key, func() string {
+		return fmt.Sprintf("%s: %v", message, value)
+	})
+}
+
+// each_cacheable reports whether value is of a basic kind, which is formatted the same way
+// every time, and is equal to itself, unlike NaN
+func each_cacheable(value any) bool {
+	if value == nil {
+		return false
+	}
+	switch reflect.TypeOf(value).Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return value == value
+	}
+	return false
+}
+
+// add_each_details defers copying details until the assertion is emitted
+func add_each_details(details map[string]any, value any) map[string]any {
+	return map[string]any{lazyDetailsKey: lazyDetails(func() map[string]any {
+		enhancedDetails := map[string]any{}
+		for k, v := range resolveDetails(details) {
+			enhancedDetails[k] = v
+		}
+		enhancedDetails["value"] = value
+		return enhancedDetails
+	})}
+}
+
+func sometimesEachImpl(ctx context.Context, loc *locationInfo, value any, message string, details map[string]any) {
+	each := each_message(message, value)
+	id := makeKey(each, loc)
+	if assertTracker.mayEmit(id, loc, true) {
+		all_details := add_each_details(with_context_details(ctx, details), value)
+		assertImpl(true, each, all_details, loc, wasHit, mustBeHit, existentialTest, sometimesDisplay, id)
+	}
+}
+
+func sometimesEachOfImpl[T any](ctx context.Context, loc *locationInfo, value T, expected []T, message string, details map[string]any) {
+	registered, ok := eachRegistrations.load(message)
+	if !ok {
+		registered = eachRegistrations.loadOrCreate(message, func() *atomic.Bool {
+			return &atomic.Bool{}
+		})
+	}
+	// The expected values are registered by the first caller, without holding any lock,
+	// since registering them calls the output handler
+	if !registered.Load() && registered.CompareAndSwap(false, true) {
+		for _, e := range expected {
+			each := each_message(message, e)
+			assertImpl(false, each, nil, loc, !wasHit, mustBeHit, existentialTest, sometimesDisplay, makeKey(each, loc))
+		}
+	}
+	sometimesEachImpl(ctx, loc, value, message, details)
+}
+
+// SometimesEach asserts that every distinct value it is called with is observed at least once. It is equivalent to calling Sometimes(true, message+": "+value, details), so a separate test property, named "<message>: <value>", is created for each value formatted with %v. Information about value will automatically be added to the details parameter, with key value.
+//
+// Values should come from a small set, such as the states of an enum. Test properties are only created for values that are observed, so use [SometimesEachOf] to also report the values that never are.
+//
+// The test properties created by SometimesEach are not known to the antithesis-go-generator utility, and are only reported once they are evaluated.
+func SometimesEach(value any, message string, details map[string]any) {
+	sometimesEachImpl(context.Background(), callerLocation(offsetAPICaller), value, message, details)
+}
+
+// SometimesEachOf is [SometimesEach] for a value from a known set of expected values. The first time it is called with a message, a test property is registered for every expected value, so that the values that are never observed are reported as failing.
+func SometimesEachOf[T any](value T, expected []T, message string, details map[string]any) {
+	sometimesEachOfImpl(context.Background(), callerLocation(offsetAPICaller), value, expected, message, details)
+}
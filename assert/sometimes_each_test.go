@@ -0,0 +1,58 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"testing"
+)
+
+func TestSometimesEach(t *testing.T) {
+	out := captureOutput(t)
+
+	for _, status := range []int{200, 404, 200, 200} {
+		SometimesEach(status, "each status", map[string]any{"path": "/"})
+	}
+
+	if len(out.assertions) != 2 {
+		t.Fatalf("emitted %d assertions, want 2", len(out.assertions))
+	}
+	if out.assertions[0].Message != "each status: 200" || out.assertions[1].Message != "each status: 404" {
+		t.Errorf("unexpected messages %q, %q", out.assertions[0].Message, out.assertions[1].Message)
+	}
+	if got := out.assertions[1].Details; got["value"] != float64(404) || got["path"] != "/" {
+		t.Errorf("unexpected details %v", got)
+	}
+	if s := summaryFor(t, "each status: 200"); s.PassCount != 3 || s.Verdict != VerdictPassed {
+		t.Errorf("unexpected summary %+v", s)
+	}
+}
+
+func TestSometimesEachOf(t *testing.T) {
+	out := captureOutput(t)
+
+	roles := []string{"leader", "follower", "candidate"}
+	SometimesEachOf("follower", roles, "each role", nil)
+	SometimesEachOf("leader", roles, "each role", nil)
+	SometimesEachOf("leader", roles, "each role", nil)
+
+	// Expected values are registered once per process, so only the hits are emitted by repeated runs
+	hits := 0
+	for _, a := range out.assertions {
+		if a.Hit {
+			hits++
+		}
+	}
+	if hits != 2 {
+		t.Errorf("emitted %d hits, want 2", hits)
+	}
+
+	for role, want := range map[string]Verdict{
+		"leader":    VerdictPassed,
+		"follower":  VerdictPassed,
+		"candidate": VerdictNeverHit,
+	} {
+		if s := summaryFor(t, "each role: "+role); s.Verdict != want {
+			t.Errorf("%s has verdict %v, want %v", role, s.Verdict, want)
+		}
+	}
+}
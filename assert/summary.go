@@ -0,0 +1,113 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"sync"
+	"syscall"
+
+	"github.com/antithesishq/antithesis-sdk-go/internal"
+)
+
+// Summary returns the local verdict of every test property evaluated or registered so far, sorted by message. It applies the same rules as the Antithesis [triage report]:
+//   - Always, AlwaysOrUnreachable and Unreachable properties fail if their condition was ever false.
+//   - Always, Sometimes and Reachable properties fail if they were never evaluated.
+//   - Sometimes properties fail if their condition was never true.
+//
+// [triage report]: https://antithesis.com/docs/reports/
+func Summary() []PropertySummary {
+	props := assertTracker.properties()
+	summaries := make([]PropertySummary, 0, len(props))
+	for _, p := range props {
+		summaries = append(summaries, PropertySummary{
+			Message:     p.Message,
+			DisplayType: p.DisplayType,
+			Verdict:     verdictFor(p),
+			Classname:   p.Classname,
+			Funcname:    p.Funcname,
+			Filename:    p.Filename,
+			Line:        p.Line,
+			PassCount:   p.PassCount,
+			FailCount:   p.FailCount,
+		})
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].Message < summaries[j].Message
+	})
+	return summaries
+}
+
+func verdictFor(p internal.Property) Verdict {
+	// Sometimes and Reachable pass once their condition has been true
+	if p.AssertType == existentialTest || (p.AssertType == reachabilityTest && p.MustHit) {
+		if p.PassCount > 0 {
+			return VerdictPassed
+		}
+		if p.FailCount > 0 {
+			return VerdictNeverTrue
+		}
+		return VerdictNeverHit
+	}
+
+	// Always, AlwaysOrUnreachable and Unreachable fail as soon as their condition is false
+	if p.FailCount > 0 {
+		return VerdictFailed
+	}
+	if p.MustHit && p.PassCount == 0 {
+		return VerdictNeverHit
+	}
+	return VerdictPassed
+}
+
+var reportSummaryOnce sync.Once
+
+// ReportSummary writes a readable report of the result of [Summary] to standard error. When the environment variable ANTITHESIS_SDK_LOCAL_OUTPUT names a local output file, the result is also written to that file as a single JSON message. The summary is not part of the Antithesis protocol, so it is never sent to the Antithesis platform, nor to a handler set with sdk.SetHandler. Only the first call has any effect.
+//
+// When the environment variable ANTITHESIS_SDK_LOCAL_SUMMARY is set to a non-empty value, ReportSummary is called automatically when the process first receives SIGINT or SIGTERM. The SDK only writes the summary: it neither stops the process nor changes the handlers the application registered with signal.Notify, which receive the signal as usual, so that graceful shutdown still happens. Since Go does not stop a process because of a signal that is being listened for, a program without handlers of its own is only stopped by a second SIGINT or SIGTERM; such programs can call ReportSummary on their own shutdown path instead of setting ANTITHESIS_SDK_LOCAL_SUMMARY. ReportSummary is not called automatically when main returns, or when the program calls os.Exit, since Go does not run any code then. To report on a normal exit, defer ReportSummary at the start of main:
+//
+//	func main() {
+//		defer assert.ReportSummary()
+//		...
+//	}
+func ReportSummary() {
+	reportSummaryOnce.Do(func() {
+		summaries := Summary()
+		internal.Json_local(map[string]any{"antithesis_summary": summaries})
+		writeSummaryText(summaries)
+	})
+}
+
+func writeSummaryText(summaries []PropertySummary) {
+	passed := 0
+	for _, s := range summaries {
+		if s.Passed() {
+			passed++
+		}
+	}
+	fmt.Fprintf(os.Stderr, "Antithesis SDK property summary: %d passed, %d failed\n", passed, len(summaries)-passed)
+	for _, s := range summaries {
+		fmt.Fprintf(os.Stderr, "  %-10s  %-19s  %q (%s:%d)\n", s.Verdict, s.DisplayType, s.Message, s.Filename, s.Line)
+	}
+}
+
+// reportSummaryOnSignal reports the summary when the process first receives SIGINT or SIGTERM.
+// The signal is left to the application, and later ones have their usual effect.
+func reportSummaryOnSignal() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-signals
+		signal.Stop(signals)
+		ReportSummary()
+	}()
+}
+
+func init() {
+	if value, is_set := os.LookupEnv(internal.LocalSummaryEnvVar); is_set && len(value) > 0 {
+		reportSummaryOnSignal()
+	}
+}
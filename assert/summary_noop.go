@@ -0,0 +1,6 @@
+//go:build no_antithesis_sdk
+
+package assert
+
+func Summary() []PropertySummary { return nil }
+func ReportSummary()             {}
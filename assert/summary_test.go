@@ -0,0 +1,88 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/antithesishq/antithesis-sdk-go/internal"
+)
+
+func summaryFor(t *testing.T, message string) PropertySummary {
+	t.Helper()
+	for _, s := range Summary() {
+		if s.Message == message {
+			return s
+		}
+	}
+	t.Fatalf("no summary for %q", message)
+	return PropertySummary{}
+}
+
+func TestSummaryVerdicts(t *testing.T) {
+	internal.ResetTrackers()
+
+	Always(true, "summary always passes", nil)
+	Always(true, "summary always fails", nil)
+	Always(false, "summary always fails", nil)
+	AlwaysOrUnreachable(true, "summary always or unreachable", nil)
+	Sometimes(false, "summary sometimes never true", nil)
+	Sometimes(false, "summary sometimes passes", nil)
+	Sometimes(true, "summary sometimes passes", nil)
+	Reachable("summary reachable", nil)
+	Unreachable("summary unreachable", nil)
+	AssertRaw(true, "summary registered reachable", nil,
+		"assert", "TestSummaryVerdicts", "summary_test.go", 1,
+		false, true, reachabilityTest, reachableDisplay, "summary registered reachable")
+	AssertRaw(false, "summary registered unreachable", nil,
+		"assert", "TestSummaryVerdicts", "summary_test.go", 1,
+		false, false, reachabilityTest, unreachableDisplay, "summary registered unreachable")
+
+	for message, want := range map[string]Verdict{
+		"summary always passes":          VerdictPassed,
+		"summary always fails":           VerdictFailed,
+		"summary always or unreachable":  VerdictPassed,
+		"summary sometimes never true":   VerdictNeverTrue,
+		"summary sometimes passes":       VerdictPassed,
+		"summary reachable":              VerdictPassed,
+		"summary unreachable":            VerdictFailed,
+		"summary registered reachable":   VerdictNeverHit,
+		"summary registered unreachable": VerdictPassed,
+	} {
+		if got := summaryFor(t, message).Verdict; got != want {
+			t.Errorf("verdict for %q = %q, want %q", message, got, want)
+		}
+	}
+
+	s := summaryFor(t, "summary always fails")
+	if s.PassCount != 1 || s.FailCount != 1 || s.DisplayType != alwaysDisplay {
+		t.Errorf("unexpected summary %+v", s)
+	}
+}
+
+func TestReportSummaryOnSignalLeavesApplicationHandlers(t *testing.T) {
+	app := make(chan os.Signal, 1)
+	signal.Notify(app, syscall.SIGTERM)
+	t.Cleanup(func() { signal.Stop(app) })
+	reportSummaryOnSignal()
+
+	p, err := os.FindProcess(os.Getpid())
+	if err == nil {
+		err = p.Signal(syscall.SIGTERM)
+	}
+	if err != nil {
+		t.Skipf("cannot signal the test process: %v", err)
+	}
+
+	// The application's handler still receives the signal, and the process is not stopped
+	select {
+	case <-app:
+	case <-time.After(10 * time.Second):
+		t.Fatal("the application's handler did not receive the signal")
+	}
+	time.Sleep(100 * time.Millisecond)
+}
@@ -0,0 +1,164 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"context"
+	"math"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	verdictUnknown int32 = iota
+	verdictTrue
+	verdictFalse
+)
+
+// thresholdInfo counts the evaluations of an assertion that is judged on
+// its history, and remembers the outcome it last emitted
+type thresholdInfo struct {
+	trues    atomic.Int64
+	total    atomic.Int64
+	emitted  atomic.Int32
+	mutex    sync.Mutex // serializes status updates
+	emitting bool       // guarded by mutex
+}
+
+type thresholdTracker struct {
+	entries readMostlyMap[string, *thresholdInfo]
+}
+
+var threshold_tracker *thresholdTracker = &thresholdTracker{}
+
+func (tracker *thresholdTracker) getTrackerEntry(messageKey string) *thresholdInfo {
+	if tI, ok := tracker.entries.load(messageKey); ok {
+		return tI
+	}
+	return tracker.entries.loadOrCreate(messageKey, func() *thresholdInfo {
+		return &thresholdInfo{}
+	})
+}
+
+func (tracker *thresholdTracker) reset() {
+	tracker.entries.rangeLocked(func(string, *thresholdInfo) bool {
+		return false
+	})
+}
+
+// count records an evaluation, and returns the number of true and total evaluations so far
+func (tI *thresholdInfo) count(cond bool) (trues, total int64) {
+	if cond {
+		trues = tI.trues.Add(1)
+	} else {
+		trues = tI.trues.Load()
+	}
+	return trues, tI.total.Add(1)
+}
+
+func verdict_of(cond bool) int32 {
+	if cond {
+		return verdictTrue
+	}
+	return verdictFalse
+}
+
+// thresholdAssertImpl is assertImpl for assertions judged on their history by holds,
+// which emits a status update every time the outcome differs from the last one emitted
+func thresholdAssertImpl(tI *thresholdInfo, trues, total int64, holds func(trues, total int64) bool,
+	message string, details func(trues, total int64) map[string]any,
+	loc *locationInfo,
+	assertType string, displayType string,
+	id string,
+) {
+	cond := holds(trues, total)
+	if tI.emitted.Load() != verdict_of(cond) {
+		// Another caller may have emitted the update already, in which case this
+		// evaluation is judged again on the counts that update was based on
+		var handled bool
+		if trues, total, handled = tI.update(holds, message, details, loc, assertType, displayType, id); handled {
+			return
+		}
+		cond = holds(trues, total)
+	}
+	if assertTracker.mayEmit(id, loc, cond) {
+		assertImpl(cond, message, details(trues, total), loc, wasHit, mustBeHit, assertType, displayType, id)
+	}
+}
+
+// update emits status updates until the last one emitted agrees with the latest counts.
+// Updates are prepared while holding the lock, and emitted once it has been released,
+// so that handlers may evaluate assertions. Only one caller emits updates at a time:
+// the others leave their evaluations to it, since it judges the latest counts again
+// after every update. handled reports whether the evaluation was taken care of either
+// way. Otherwise, trues and total are the counts that the last update agrees with.
+func (tI *thresholdInfo) update(holds func(trues, total int64) bool,
+	message string, details func(trues, total int64) map[string]any,
+	loc *locationInfo,
+	assertType string, displayType string,
+	id string,
+) (trues, total int64, handled bool) {
+	tI.mutex.Lock()
+	defer tI.mutex.Unlock()
+	if tI.emitting {
+		return 0, 0, true
+	}
+
+	for {
+		trues = tI.trues.Load()
+		total = tI.total.Load()
+		cond := holds(trues, total)
+		if tI.emitted.Load() == verdict_of(cond) {
+			return trues, total, handled
+		}
+		trackerEntry := assertTracker.getTrackerEntry(id, loc.Filename, loc.Classname)
+		out := trackerEntry.prepareUpdate(newAssertInfo(trackerEntry, cond, message, details(trues, total), loc, wasHit, mustBeHit, assertType, displayType, id))
+		tI.emitted.Store(verdict_of(cond))
+		handled = true
+
+		tI.emitting = true
+		tI.mutex.Unlock()
+		out.emit()
+		tI.mutex.Lock()
+		tI.emitting = false
+	}
+}
+
+// SometimesAtLeast asserts that condition is true at least n times over all the calls to this function. It is equivalent to counting the calls where condition is true, and asserting Sometimes(count >= n, message, details) after each of them. Information about the count will automatically be added to the details parameter, with keys count and n. Antithesis is guided towards increasing the count, which may help it find more bugs.
+func SometimesAtLeast(condition bool, n int, message string, details map[string]any) {
+	sometimesAtLeastImpl(context.Background(), callerLocation(offsetAPICaller), condition, n, message, details)
+}
+
+func sometimesAtLeastImpl(ctx context.Context, loc *locationInfo, condition bool, n int, message string, details map[string]any) {
+	id := makeKey(message, loc)
+	tI := threshold_tracker.getTrackerEntry(id)
+	count, total := tI.count(condition)
+	thresholdAssertImpl(tI, count, total, func(count, _ int64) bool {
+		return count >= int64(n)
+	}, message, func(count, _ int64) map[string]any {
+		return add_extra_details(with_context_details(ctx, details), map[string]any{"count": count, "n": n})
+	}, loc, existentialTest, sometimesDisplay, id)
+
+	numericGuidanceImpl(count, int64(n), message, id, loc, guidanceFnMaximize, wasHit)
+}
+
+// AlwaysFractionBelow asserts that the fraction of all the calls to this function where condition is true stays below ratio. For example, to assert that at most 1% of requests fail, call AlwaysFractionBelow(err != nil, 0.01, message, details) for every request. Information about the fraction will automatically be added to the details parameter, with keys count, total, fraction and ratio.
+//
+// The fraction is only judged once there have been at least 1/ratio calls, since before then a single true condition would exceed it. ratio should be between 0 and 1: a ratio of 0 or less can never be met, so the assertion fails on the first call, and a ratio above 1 is always met. The assertion is emitted again each time the fraction crosses ratio, in either direction. Antithesis is guided towards increasing the fraction, which may help it find more bugs.
+func AlwaysFractionBelow(condition bool, ratio float64, message string, details map[string]any) {
+	alwaysFractionBelowImpl(context.Background(), callerLocation(offsetAPICaller), condition, ratio, message, details)
+}
+
+func alwaysFractionBelowImpl(ctx context.Context, loc *locationInfo, condition bool, ratio float64, message string, details map[string]any) {
+	id := makeKey(message, loc)
+	tI := threshold_tracker.getTrackerEntry(id)
+	count, total := tI.count(condition)
+	thresholdAssertImpl(tI, count, total, func(count, total int64) bool {
+		return float64(count)/float64(total) < ratio || (ratio > 0 && float64(total) < math.Ceil(1/ratio))
+	}, message, func(count, total int64) map[string]any {
+		fraction := float64(count) / float64(total)
+		return add_extra_details(with_context_details(ctx, details), map[string]any{"count": count, "total": total, "fraction": fraction, "ratio": ratio})
+	}, loc, universalTest, alwaysDisplay, id)
+
+	numericGuidanceImpl(float64(count)/float64(total), ratio, message, id, loc, guidanceFnMaximize, wasHit)
+}
@@ -0,0 +1,115 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/antithesishq/antithesis-sdk-go/internal"
+)
+
+// lockedOutput collects emitted assertions from multiple goroutines
+type lockedOutput struct {
+	mutex sync.Mutex
+	capturedOutput
+}
+
+func (l *lockedOutput) Output(message string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.capturedOutput.Output(message)
+}
+
+func conditions(assertions []assertInfo) []bool {
+	var conds []bool
+	for _, a := range assertions {
+		conds = append(conds, a.Condition)
+	}
+	return conds
+}
+
+func TestSometimesAtLeast(t *testing.T) {
+	out := captureOutput(t)
+
+	for i := 0; i < 10; i++ {
+		SometimesAtLeast(i%2 == 0, 3, "at least three", nil)
+	}
+
+	got := conditions(out.assertions)
+	if len(got) != 2 || got[0] || !got[1] {
+		t.Fatalf("emitted conditions %v, want [false true]", got)
+	}
+	if count := out.assertions[1].Details["count"]; count != float64(3) {
+		t.Errorf("passed with count %v, want 3", count)
+	}
+	if s := summaryFor(t, "at least three"); s.Verdict != VerdictPassed || s.PassCount != 6 || s.FailCount != 4 {
+		t.Errorf("unexpected summary %+v", s)
+	}
+	if n := len(out.guidance); n != 5 {
+		t.Errorf("sent guidance %d times, want 5", n)
+	}
+}
+
+func TestAlwaysFractionBelow(t *testing.T) {
+	out := captureOutput(t)
+
+	// Not judged before 3 calls, then 1/3 passes, 2/4 fails, 2/5 passes, and 4/8 fails
+	for _, failed := range []bool{true, false, false, true, false, false, true, true} {
+		AlwaysFractionBelow(failed, 0.45, "fraction below", nil)
+	}
+
+	got := conditions(out.assertions)
+	want := []bool{true, false, true, false}
+	if len(got) != len(want) {
+		t.Fatalf("emitted conditions %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("emitted conditions %v, want %v", got, want)
+		}
+	}
+	last := out.assertions[3].Details
+	if last["count"] != float64(4) || last["total"] != float64(8) || last["ratio"] != 0.45 {
+		t.Errorf("unexpected details %v", last)
+	}
+}
+
+func TestAlwaysFractionBelowNonPositiveRatio(t *testing.T) {
+	out := captureOutput(t)
+
+	for _, ratio := range []float64{0, -0.5} {
+		AlwaysFractionBelow(false, ratio, "fraction below non-positive ratio", nil)
+	}
+
+	got := conditions(out.assertions)
+	if len(got) != 1 || got[0] {
+		t.Fatalf("emitted conditions %v, want [false]", got)
+	}
+}
+
+func TestAlwaysFractionBelowConcurrentUpdates(t *testing.T) {
+	out := &lockedOutput{}
+	captureOutput(t)
+	internal.SetOutputHandler(out)
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				AlwaysFractionBelow((g+i)%2 == 0, 0.5, "fraction below concurrently", nil)
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	// Exactly half of the calls were true, so the last update emitted must be a failure
+	out.mutex.Lock()
+	defer out.mutex.Unlock()
+	got := conditions(out.assertions)
+	if len(got) == 0 || got[len(got)-1] {
+		t.Fatalf("emitted conditions %v, want the last one to be false", got)
+	}
+}
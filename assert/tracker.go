@@ -3,6 +3,7 @@
 package assert
 
 import (
+	"fmt"
 	"runtime"
 	"sync"
 	"sync/atomic"
@@ -11,53 +12,99 @@ import (
 )
 
 type trackerInfo struct {
-	Filename  string
-	Classname string
-	PassCount int
-	FailCount int
+	Filename    string
+	Classname   string
+	Funcname    string
+	AssertType  string
+	DisplayType string
+	Line        int
+	MustHit     bool
+	Registered  bool
+	PassCount   atomic.Int64
+	FailCount   atomic.Int64
 }
 
-type emitTracker map[string]*trackerInfo
+type emitTracker struct {
+	entries readMostlyMap[string, *trackerInfo]
+}
 
 // assert_tracker (global) keeps track of the unique asserts evaluated
 var (
-	assertTracker    emitTracker = make(emitTracker)
-	trackerMutex     sync.Mutex
+	assertTracker    *emitTracker = &emitTracker{}
 	trackerInfoMutex sync.Mutex
 )
 
-func (tracker emitTracker) getTrackerEntry(messageKey string, filename, classname string) *trackerInfo {
-	var trackerEntry *trackerInfo
-	var ok bool
-
+func (tracker *emitTracker) getTrackerEntry(messageKey string, filename, classname string) *trackerInfo {
 	if tracker == nil {
 		return nil
 	}
 
-	trackerMutex.Lock()
-	defer trackerMutex.Unlock()
-	if trackerEntry, ok = tracker[messageKey]; !ok {
-		trackerEntry = newTrackerInfo(filename, classname)
-		tracker[messageKey] = trackerEntry
+	if trackerEntry, ok := tracker.entries.load(messageKey); ok {
+		return trackerEntry
 	}
-	return trackerEntry
+	return tracker.entries.loadOrCreate(messageKey, func() *trackerInfo {
+		return newTrackerInfo(filename, classname)
+	})
 }
 
 func newTrackerInfo(filename, classname string) *trackerInfo {
 	trackerInfo := trackerInfo{
-		PassCount: 0,
-		FailCount: 0,
 		Filename:  filename,
 		Classname: classname,
 	}
 	return &trackerInfo
 }
 
+// mayEmit reports whether an evaluation of the assertion with this outcome
+// could be emitted. When it cannot, the evaluation is counted without
+// locking or allocating, and the caller need not build the assertion.
+func (tracker *emitTracker) mayEmit(messageKey string, loc *locationInfo, cond bool) bool {
+	trackerEntry := tracker.getTrackerEntry(messageKey, loc.Filename, loc.Classname)
+	return !trackerEntry.countIfEmitted(cond)
+}
+
+// countIfEmitted counts a hit if an evaluation with the same outcome has already been emitted
+func (ti *trackerInfo) countIfEmitted(cond bool) bool {
+	if ti == nil {
+		return false
+	}
+	count := &ti.FailCount
+	if cond {
+		count = &ti.PassCount
+	}
+	if count.Load() == 0 {
+		return false
+	}
+	count.Add(1)
+	return true
+}
+
+// describe records the attributes of the assertion the first time they are known.
+// Must be called with trackerInfoMutex held.
+func (ti *trackerInfo) describe(ai *assertInfo) {
+	if ti.AssertType == "" {
+		ti.AssertType = ai.AssertType
+		ti.DisplayType = ai.DisplayType
+		ti.MustHit = ai.MustHit
+		if ai.Location != nil {
+			ti.Funcname = ai.Location.Funcname
+			ti.Line = ai.Location.Line
+		}
+	}
+	if !ai.Hit {
+		ti.Registered = true
+	}
+}
+
 func (ti *trackerInfo) emit(ai *assertInfo) {
 	if ti == nil || ai == nil {
 		return
 	}
 
+	trackerInfoMutex.Lock()
+	ti.describe(ai)
+	trackerInfoMutex.Unlock()
+
 	// Registrations are just sent to voidstar
 	hit := ai.Hit
 	if !hit {
@@ -66,28 +113,112 @@ func (ti *trackerInfo) emit(ai *assertInfo) {
 	}
 
 	var err error
+	var out assertOutput
 	cond := ai.Condition
+	count := &ti.FailCount
+	if cond {
+		count = &ti.PassCount
+	}
 
+	// Evaluate any lazy details, and the methods of the values in them, outside
+	// of the lock, and only when this is likely to be the first pass or failure
+	if count.Load() == 0 {
+		ai.normalizeDetails()
+	}
+
+	// The assertion is only marshaled while holding the lock, and is emitted
+	// once it has been released, so that handlers may evaluate assertions
 	trackerInfoMutex.Lock()
-	defer trackerInfoMutex.Unlock()
-	if cond {
-		if ti.PassCount == 0 {
-			err = emitAssert(ai)
-		}
-		if err == nil {
-			ti.PassCount++
-		}
-		return
+	if count.Load() == 0 {
+		out, err = prepareAssert(ai)
+	}
+	if err == nil {
+		count.Add(1)
+	}
+	trackerInfoMutex.Unlock()
+	out.emit()
+}
+
+// prepareUpdate prepares a hit even when an evaluation with the same outcome has
+// already been emitted, for assertions whose verdict can change back. The caller
+// emits the result once it has released any locks of its own.
+func (ti *trackerInfo) prepareUpdate(ai *assertInfo) assertOutput {
+	if ti == nil || ai == nil {
+		return nil
 	}
-	if ti.FailCount == 0 {
-		err = emitAssert(ai)
+
+	trackerInfoMutex.Lock()
+	ti.describe(ai)
+	trackerInfoMutex.Unlock()
+
+	count := &ti.FailCount
+	if ai.Condition {
+		count = &ti.PassCount
 	}
+	ai.normalizeDetails()
+
+	trackerInfoMutex.Lock()
+	defer trackerInfoMutex.Unlock()
+	out, err := prepareAssert(ai)
 	if err == nil {
-		ti.FailCount++
+		count.Add(1)
 	}
+	return out
+}
+
+// properties provides a snapshot of the tracker for internal.Properties
+func (tracker *emitTracker) properties() []internal.Property {
+	trackerInfoMutex.Lock()
+	defer trackerInfoMutex.Unlock()
+
+	var props []internal.Property
+	tracker.entries.rangeLocked(func(message string, ti *trackerInfo) bool {
+		props = append(props, internal.Property{
+			Message:     message,
+			AssertType:  ti.AssertType,
+			DisplayType: ti.DisplayType,
+			Classname:   ti.Classname,
+			Funcname:    ti.Funcname,
+			Filename:    ti.Filename,
+			Line:        ti.Line,
+			MustHit:     ti.MustHit,
+			Registered:  ti.Registered,
+			PassCount:   int(ti.PassCount.Load()),
+			FailCount:   int(ti.FailCount.Load()),
+		})
+		return true
+	})
+	return props
+}
+
+// reset clears the pass and fail counts, and drops entries which
+// were not registered by the assertion catalog
+func (tracker *emitTracker) reset() {
+	trackerInfoMutex.Lock()
+	defer trackerInfoMutex.Unlock()
+
+	tracker.entries.rangeLocked(func(_ string, ti *trackerInfo) bool {
+		ti.PassCount.Store(0)
+		ti.FailCount.Store(0)
+		return ti.Registered
+	})
 }
 
-func versionMessage() {
+func init() {
+	internal.RegisterPropertiesSource(assertTracker.properties)
+	internal.RegisterResetHook(assertTracker.reset)
+	internal.RegisterResetHook(numeric_guidance_tracker.reset)
+	internal.RegisterResetHook(boolean_guidance_tracker.reset)
+	internal.RegisterResetHook(explore_guidance_tracker.reset)
+	internal.RegisterResetHook(threshold_tracker.reset)
+	internal.RegisterResetHook(monotonic_tracker.reset)
+	internal.RegisterResetHook(unique_tracker.reset)
+	internal.RegisterResetHook(resetOrderings)
+	internal.RegisterEventObserver(observeOrderings)
+	internal.RegisterDetailsNormalizer(normalize)
+}
+
+func prepareVersion() internal.Output {
 	languageBlock := map[string]any{
 		"name":    "Go",
 		"version": runtime.Version(),
@@ -97,15 +228,45 @@ func versionMessage() {
 		"sdk_version":      internal.SDK_Version,
 		"protocol_version": internal.Protocol_Version,
 	}
-	internal.Json_data(map[string]any{"antithesis_sdk": versionBlock})
+	return internal.Prepare_json(map[string]any{"antithesis_sdk": versionBlock})
 }
 
 // package-level flag
 var hasEmitted atomic.Bool // initialzed to false
 
-func emitAssert(ai *assertInfo) error {
-	if hasEmitted.CompareAndSwap(false, true) {
-		versionMessage()
+// assertOutput is an assertion that has been marshaled, along with anything
+// that must be emitted before it
+type assertOutput []internal.Output
+
+func (out assertOutput) emit() {
+	for _, o := range out {
+		o.Emit()
 	}
-	return internal.Json_data(wrappedAssertInfo{ai})
+}
+
+func prepareAssert(ai *assertInfo) (assertOutput, error) {
+	var out assertOutput
+	// A crash monitor does not send the version again
+	if hasEmitted.CompareAndSwap(false, true) && !internal.IsCrashMonitor() {
+		out = append(out, prepareVersion())
+	}
+	prepared := internal.Prepare_json(wrappedAssertInfo{ai})
+	out = append(out, prepared)
+	err := prepared.Err()
+	if err != nil && ai.Details != nil {
+		// Emit the assertion without its details, so that the property is still reported
+		fallback := *ai
+		fallback.Details = map[string]any{"details_error": fmt.Sprintf("details could not be emitted: %v", err)}
+		fallback.normalized = false
+		prepared = internal.Prepare_json(wrappedAssertInfo{&fallback})
+		out = append(out, prepared)
+		err = prepared.Err()
+	}
+	return out, err
+}
+
+func emitAssert(ai *assertInfo) error {
+	out, err := prepareAssert(ai)
+	out.emit()
+	return err
 }
@@ -0,0 +1,104 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/antithesishq/antithesis-sdk-go/internal"
+)
+
+func TestEmitAssertDetailsFallback(t *testing.T) {
+	out := captureOutput(t)
+
+	// Lazy details are normally resolved before emitting, so this panics while marshaling
+	loc := callerLocation(offsetHere)
+	details := LazyDetails(func() map[string]any { panic("bad details") })
+	ai := &assertInfo{Location: loc, Details: details, Message: "fallback", Id: "fallback", Hit: true}
+	if err := emitAssert(ai); err != nil {
+		t.Fatalf("fallback not emitted: %v", err)
+	}
+
+	if len(out.assertions) != 1 {
+		t.Fatalf("emitted %d assertions, want 1", len(out.assertions))
+	}
+	got := out.assertions[0]
+	description, _ := got.Details["details_error"].(string)
+	if got.Message != "fallback" || !strings.Contains(description, "bad details") {
+		t.Errorf("unexpected assertion %+v", got)
+	}
+}
+
+// reentrantHandler evaluates assertions while handling output
+type reentrantHandler struct {
+	messages int
+}
+
+func (h *reentrantHandler) Output(message string) {
+	h.messages++
+	if strings.Contains(message, `"antithesis_assert"`) {
+		Reachable("reentrant handler", nil)
+		AlwaysLessThan(h.messages, 1000, "reentrant handler guidance", nil)
+		SometimesEachOf("a", []string{"a", "b"}, "reentrant handler each", nil)
+	}
+}
+
+func TestHandlerMayEvaluateAssertions(t *testing.T) {
+	internal.ResetTrackers()
+	h := &reentrantHandler{}
+	internal.SetOutputHandler(h)
+	t.Cleanup(func() { internal.SetOutputHandler(nil) })
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		Always(true, "reentrant always", nil)
+		SometimesAtLeast(true, 1, "reentrant threshold", nil)
+		SometimesEachOf("x", []string{"x", "y"}, "reentrant each", nil)
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("assertions evaluated by a handler deadlocked")
+	}
+	if h.messages == 0 {
+		t.Error("no output was handled")
+	}
+}
+
+func TestErrorHandlerMayEvaluateAssertions(t *testing.T) {
+	out := captureOutput(t)
+	internal.SetEmitErrorHandler(func(err error) {
+		Reachable("error handler", nil)
+	})
+	t.Cleanup(func() { internal.SetEmitErrorHandler(nil) })
+
+	// Details that are marked as normalized are marshaled as they are, so this
+	// fails while the assertion is being prepared
+	loc := callerLocation(offsetHere)
+	trackerEntry := assertTracker.getTrackerEntry("unmarshalable", loc.Filename, loc.Classname)
+	ai := newAssertInfo(trackerEntry, true, "unmarshalable", map[string]any{"f": func() {}}, loc, wasHit, mustBeHit, universalTest, alwaysDisplay, "unmarshalable")
+	ai.normalized = true
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		trackerEntry.emit(ai)
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("assertion evaluated by an error handler deadlocked")
+	}
+
+	// The failure is reported before the assertion is emitted without its details
+	var messages []string
+	for _, a := range out.assertions {
+		messages = append(messages, a.Message)
+	}
+	if len(messages) != 2 || messages[0] != "error handler" || messages[1] != "unmarshalable" {
+		t.Errorf("emitted assertions %v", messages)
+	}
+}
@@ -0,0 +1,125 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"context"
+	"fmt"
+	"hash/maphash"
+	"sync"
+)
+
+const (
+	// uniqueExactLimit is the number of identifiers remembered exactly, with the context
+	// they were issued in, for each namespace
+	uniqueExactLimit = 1 << 16
+
+	// Identifiers beyond uniqueExactLimit are added to a bloom filter of uniqueBloomBits
+	// bits, probed uniqueBloomProbes times. This takes 1 MiB per namespace, and has a false
+	// positive rate below 1 in 10000 until a namespace holds a quarter of a million identifiers.
+	uniqueBloomBits   = 1 << 23
+	uniqueBloomProbes = 5
+)
+
+// issuance is the context an identifier was first seen in. Its details are not kept,
+// since that would hold on to whatever they refer to for as long as the identifier is
+// remembered, and report lazy details long after they were current.
+type issuance struct {
+	loc     *locationInfo
+	message string
+}
+
+func (is *issuance) describe() map[string]any {
+	return map[string]any{
+		"message":  is.message,
+		"location": fmt.Sprintf("%s:%d", is.loc.Filename, is.loc.Line),
+		"function": is.loc.Funcname,
+	}
+}
+
+// uniqueSet is the set of identifiers seen in a namespace
+type uniqueSet struct {
+	mutex sync.Mutex
+	exact map[any]*issuance
+	bloom []uint64 // allocated once exact is full
+}
+
+type uniqueTracker struct {
+	namespaces readMostlyMap[string, *uniqueSet]
+	seed       maphash.Seed
+}
+
+var unique_tracker *uniqueTracker = &uniqueTracker{seed: maphash.MakeSeed()}
+
+func (tracker *uniqueTracker) getSet(namespace string) *uniqueSet {
+	if set, ok := tracker.namespaces.load(namespace); ok {
+		return set
+	}
+	return tracker.namespaces.loadOrCreate(namespace, func() *uniqueSet {
+		return &uniqueSet{exact: map[any]*issuance{}}
+	})
+}
+
+func (tracker *uniqueTracker) reset() {
+	tracker.namespaces.rangeLocked(func(string, *uniqueSet) bool {
+		return false
+	})
+}
+
+// add records id, and reports whether it was already present. When it was remembered
+// exactly, the context it was first issued in is returned.
+func (set *uniqueSet) add(id any, hash uint64, is *issuance) (seen bool, first *issuance) {
+	set.mutex.Lock()
+	defer set.mutex.Unlock()
+	if first, ok := set.exact[id]; ok {
+		return true, first
+	}
+	if len(set.exact) < uniqueExactLimit {
+		set.exact[id] = is
+		return false, nil
+	}
+	if set.bloom == nil {
+		set.bloom = make([]uint64, uniqueBloomBits/64)
+	}
+	// Derive the probes from the two halves of the hash (Kirsch and Mitzenmacher)
+	h1, h2 := hash, (hash>>32)|1
+	seen = true
+	for i := uint64(0); i < uniqueBloomProbes; i++ {
+		bit := (h1 + i*h2) % uniqueBloomBits
+		word, mask := bit/64, uint64(1)<<(bit%64)
+		if set.bloom[word]&mask == 0 {
+			seen = false
+			set.bloom[word] |= mask
+		}
+	}
+	return seen, nil
+}
+
+func alwaysUniqueImpl[T comparable](ctx context.Context, loc *locationInfo, namespace string, id T, message string, details map[string]any) {
+	messageKey := makeKey(message, loc)
+	is := &issuance{loc: loc, message: message}
+	seen, first := unique_tracker.getSet(namespace).add(id, maphash.Comparable(unique_tracker.seed, id), is)
+	condition := !seen
+	if assertTracker.mayEmit(messageKey, loc, condition) {
+		extra := map[string]any{"namespace": namespace, "id": id}
+		if seen {
+			extra["second"] = is.describe()
+			if first != nil {
+				extra["first"] = first.describe()
+			} else {
+				extra["first"] = "unknown, too many identifiers to remember exactly"
+			}
+		}
+		all_details := add_extra_details(with_context_details(ctx, details), extra)
+		assertImpl(condition, message, all_details, loc, wasHit, mustBeHit, universalTest, alwaysDisplay, messageKey)
+	}
+}
+
+// AlwaysUnique asserts that id has not been passed to AlwaysUnique before with the same namespace. It is equivalent to asserting Always(!seen[namespace][id], message, details), and is intended for identifiers that the system must never issue twice, such as transaction IDs or lease tokens. Information about the identifier will automatically be added to the details parameter, with keys namespace and id. When id is a duplicate, the context of both issuances is added with keys first and second, including the message and location of each.
+//
+// Every AlwaysUnique assertion with the same namespace shares the same set of identifiers, whatever its message. The first 65536 identifiers in each namespace are remembered exactly. Beyond that, identifiers are remembered by a fixed size probabilistic filter, so that memory stays bounded: duplicates are still always detected, but the context of the first issuance is unknown, and very rarely an identifier is reported as a duplicate when it is not.
+//
+// Only the message and location of the first issuance of each identifier are kept, not its details, so that remembering identifiers does not keep the values in their details alive.
+func AlwaysUnique[T comparable](namespace string, id T, message string, details map[string]any) {
+	alwaysUniqueImpl(context.Background(), callerLocation(offsetAPICaller), namespace, id, message, details)
+}
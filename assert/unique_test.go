@@ -0,0 +1,73 @@
+//go:build !no_antithesis_sdk
+
+package assert
+
+import (
+	"testing"
+)
+
+func TestAlwaysUnique(t *testing.T) {
+	out := captureOutput(t)
+
+	AlwaysUnique("txn", 1, "unique txn", map[string]any{"node": "a"})
+	AlwaysUnique("txn", 2, "unique txn", map[string]any{"node": "a"})
+	AlwaysUnique("other", 1, "unique other", nil)
+	AlwaysUnique("txn", 1, "unique txn retry", map[string]any{"node": "b"})
+
+	if len(out.assertions) != 3 {
+		t.Fatalf("emitted %d assertions, want 3", len(out.assertions))
+	}
+	duplicate := out.assertions[2]
+	if duplicate.Condition || duplicate.Details["id"] != float64(1) || duplicate.Details["namespace"] != "txn" {
+		t.Fatalf("unexpected duplicate %+v", duplicate)
+	}
+	first := duplicate.Details["first"].(map[string]any)
+	second := duplicate.Details["second"].(map[string]any)
+	if first["message"] != "unique txn" || first["details"] != nil {
+		t.Errorf("unexpected first issuance %v", first)
+	}
+	if second["message"] != "unique txn retry" || duplicate.Details["node"] != "b" {
+		t.Errorf("unexpected second issuance %v, details %v", second, duplicate.Details)
+	}
+}
+
+func TestUniqueSetBeyondExactLimit(t *testing.T) {
+	unique_tracker.reset()
+	t.Cleanup(unique_tracker.reset)
+
+	set := unique_tracker.getSet("bounded")
+	for i := 0; i < uniqueExactLimit+1000; i++ {
+		if seen, _ := set.add(i, uint64(i)*0x9e3779b97f4a7c15, &issuance{}); seen {
+			t.Fatalf("identifier %d reported as seen", i)
+		}
+	}
+	if len(set.exact) != uniqueExactLimit || set.bloom == nil {
+		t.Fatalf("remembered %d identifiers exactly", len(set.exact))
+	}
+	for _, i := range []int{0, uniqueExactLimit - 1, uniqueExactLimit, uniqueExactLimit + 999} {
+		seen, first := set.add(i, uint64(i)*0x9e3779b97f4a7c15, &issuance{})
+		if !seen {
+			t.Errorf("duplicate identifier %d not detected", i)
+		}
+		if exact := i < uniqueExactLimit; exact != (first != nil) {
+			t.Errorf("identifier %d has first issuance %v", i, first)
+		}
+	}
+}
+
+func TestAlwaysUniqueDoesNotKeepDetails(t *testing.T) {
+	captureOutput(t)
+
+	evaluations := 0
+	lazy := LazyDetails(func() map[string]any {
+		evaluations++
+		return map[string]any{"evaluation": evaluations}
+	})
+	AlwaysUnique("kept", 1, "unique kept", lazy)
+	AlwaysUnique("kept", 1, "unique kept", nil)
+
+	// The details of the first issuance are only evaluated when it is reported as passing
+	if evaluations != 1 {
+		t.Errorf("details evaluated %d times, want 1", evaluations)
+	}
+}
@@ -0,0 +1,32 @@
+//go:build !no_antithesis_sdk
+
+package internal
+
+import (
+	"os"
+	"sync/atomic"
+)
+
+// The crash monitor started by assert.EnableCrashReports is another run of the program
+// it monitors, so it runs the same package initializers. Until it reports a crash, it
+// emits nothing: the monitored process has already registered the assertion catalog
+// and coverage, and sent the SDK version.
+var (
+	crash_monitor       bool
+	crash_monitor_muted atomic.Bool
+)
+
+func init() {
+	_, crash_monitor = os.LookupEnv(CrashMonitorEnvVar)
+	crash_monitor_muted.Store(crash_monitor)
+}
+
+// IsCrashMonitor reports whether this process is a crash monitor
+func IsCrashMonitor() bool {
+	return crash_monitor
+}
+
+// UnmuteCrashMonitor lets a crash monitor emit its report
+func UnmuteCrashMonitor() {
+	crash_monitor_muted.Store(false)
+}
@@ -0,0 +1,23 @@
+//go:build !no_antithesis_sdk
+
+package internal
+
+import (
+	"sync/atomic"
+)
+
+var detailsNormalizer atomic.Pointer[func(details any) any]
+
+// RegisterDetailsNormalizer is called by the assert package, so that the details of
+// lifecycle events are normalized and redacted like the details of assertions
+func RegisterDetailsNormalizer(normalizer func(details any) any) {
+	detailsNormalizer.Store(&normalizer)
+}
+
+// NormalizeDetails returns details as they should be emitted
+func NormalizeDetails(details any) any {
+	if normalizer := detailsNormalizer.Load(); normalizer != nil {
+		return (*normalizer)(details)
+	}
+	return details
+}
@@ -4,29 +4,100 @@ package internal
 
 import (
 	"encoding/json"
+	"fmt"
 	"log"
 	"math/rand"
 	"os"
 )
 
+// Json_data emits v as JSON. Failures are reported as well as returned, so callers
+// do not need to report them again.
 func Json_data(v any) error {
-	if data, err := json.Marshal(v); err != nil {
-		return err
-	} else {
-		handler.output(string(data))
+	return Prepare_json(v).Emit()
+}
+
+// Output is JSON that has been marshaled by Prepare_json, but not yet emitted.
+// Callers which hold a lock prepare their output while holding it, and emit it
+// once the lock is released, so that output and error handlers are never called
+// with SDK locks held, and may themselves call into the SDK.
+type Output struct {
+	data []byte
+	err  error
+}
+
+// Prepare_json marshals v for a later call to Emit
+func Prepare_json(v any) Output {
+	data, err := marshal(v)
+	return Output{data, err}
+}
+
+// Err returns the error marshaling the output, if any
+func (o Output) Err() error {
+	return o.err
+}
+
+// Bytes returns the marshaled JSON, or nil if it could not be marshaled
+func (o Output) Bytes() []byte {
+	return o.data
+}
+
+// Emit emits the output, or reports the failure to marshal it. Emitting the
+// zero Output does nothing.
+func (o Output) Emit() error {
+	if o.err != nil {
+		reportEmitError(o.err)
+		return o.err
+	}
+	if o.data != nil {
+		emitOutput(string(o.data))
+	}
+	return nil
+}
+
+// Json_local writes v as JSON to the local output file, for output that is not
+// part of the protocol. Nothing is written when running in Antithesis, or when
+// there is no local output file. Handlers set with SetOutputHandler do not
+// receive it.
+func Json_local(v any) error {
+	local, ok := handler.(*localHandler)
+	if !ok || local.outputFile == nil {
 		return nil
 	}
+	o := Prepare_json(v)
+	if o.err != nil {
+		reportEmitError(o.err)
+		return o.err
+	}
+	local.output(string(o.data))
+	return nil
+}
+
+// marshal is json.Marshal, except that a panic while marshaling (for example, in
+// a MarshalJSON method) is returned as an error
+func marshal(v any) (data []byte, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic while marshaling: %v", r)
+		}
+	}()
+	return json.Marshal(v)
 }
 
 func Get_random() uint64 {
-	return handler.random()
+	return emitRandom()
 }
 
 func Notify(edge uint64) bool {
+	if crash_monitor {
+		return false
+	}
 	return handler.notify(edge)
 }
 
 func InitCoverage(num_edges uint64, symbols string) uint64 {
+	if crash_monitor {
+		return 0
+	}
 	return handler.init_coverage(num_edges, symbols)
 }
 
@@ -53,7 +124,9 @@ func (h *localHandler) output(message string) {
 		return
 	}
 	if h.outputFile != nil {
-		h.outputFile.WriteString(message + "\n")
+		if _, err := h.outputFile.WriteString(message + "\n"); err != nil {
+			reportEmitError(err)
+		}
 	}
 }
 
@@ -86,6 +159,16 @@ func openLocalHandler() *localHandler {
 		return &localHandler{nil}
 	}
 
+	// A crash monitor appends to the output of the process it monitors
+	if _, is_monitor := os.LookupEnv(CrashMonitorEnvVar); is_monitor {
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+		if err != nil {
+			log.Printf("%s Failed to open path %s: %v", errorLogLinePrefix, path, err)
+			file = nil
+		}
+		return &localHandler{file}
+	}
+
 	// Open the file R/W (create if needed and possible)
 	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
 	if err != nil {
@@ -0,0 +1,42 @@
+//go:build !no_antithesis_sdk
+
+package internal
+
+import (
+	"log"
+	"sync/atomic"
+)
+
+var (
+	emitErrors       atomic.Uint64
+	emitErrorLogged  atomic.Bool
+	emitErrorHandler atomic.Pointer[func(err error)]
+)
+
+// reportEmitError records a failure to emit output. The first failure is logged,
+// and every failure is passed to the handler set with SetEmitErrorHandler. It must
+// not be called with any SDK lock held, since the handler may call into the SDK.
+func reportEmitError(err error) {
+	emitErrors.Add(1)
+	if emitErrorLogged.CompareAndSwap(false, true) {
+		log.Printf("%s Failed to emit output: %v (further failures are counted, but not logged)", errorLogLinePrefix, err)
+	}
+	if h := emitErrorHandler.Load(); h != nil {
+		(*h)(err)
+	}
+}
+
+// EmitErrors returns the number of failures to emit output so far
+func EmitErrors() uint64 {
+	return emitErrors.Load()
+}
+
+// SetEmitErrorHandler calls h with every subsequent failure to emit output.
+// Passing nil removes the handler.
+func SetEmitErrorHandler(h func(err error)) {
+	if h == nil {
+		emitErrorHandler.Store(nil)
+		return
+	}
+	emitErrorHandler.Store(&h)
+}
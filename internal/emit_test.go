@@ -68,3 +68,72 @@ func TestVoidstarHandlerErr3(t *testing.T) {
 		panic("Should failed to load library")
 	}
 }
+
+func TestEmitErrors(t *testing.T) {
+	var reported []error
+	SetEmitErrorHandler(func(err error) { reported = append(reported, err) })
+	defer SetEmitErrorHandler(nil)
+	before := EmitErrors()
+
+	if err := Json_data(map[string]any{"f": func() {}}); err == nil {
+		panic("Should fail to marshal a function")
+	}
+
+	// A write to a closed file fails
+	file, err := os.CreateTemp(t.TempDir(), "antithesis-test")
+	if err != nil {
+		panic(err)
+	}
+	file.Close()
+	(&localHandler{file}).output("{}")
+
+	if EmitErrors()-before != 2 || len(reported) != 2 {
+		t.Fatalf("reported %v, counted %d errors", reported, EmitErrors()-before)
+	}
+}
+
+type panicsWhenMarshaled struct{}
+
+func (panicsWhenMarshaled) MarshalJSON() ([]byte, error) {
+	panic("boom")
+}
+
+func TestJsonDataRecoversMarshalPanic(t *testing.T) {
+	if err := Json_data(panicsWhenMarshaled{}); err == nil {
+		panic("Should fail to marshal")
+	}
+}
+
+type countingHandler struct {
+	messages int
+}
+
+func (c *countingHandler) Output(message string) {
+	c.messages++
+}
+
+func TestJsonLocalOnlyWritesLocalFile(t *testing.T) {
+	file, err := os.CreateTemp(t.TempDir(), "antithesis-test")
+	if err != nil {
+		panic(err)
+	}
+	defer file.Close()
+
+	saved := handler
+	handler = &localHandler{file}
+	defer func() { handler = saved }()
+	c := &countingHandler{}
+	SetOutputHandler(c)
+	defer SetOutputHandler(nil)
+
+	if err := Json_local(map[string]any{"local": true}); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(file.Name())
+	if err != nil {
+		panic(err)
+	}
+	if string(data) != "{\"local\":true}\n" || c.messages != 0 {
+		t.Errorf("wrote %q to the local file and %d messages to the handler", data, c.messages)
+	}
+}
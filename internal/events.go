@@ -0,0 +1,29 @@
+//go:build !no_antithesis_sdk
+
+package internal
+
+import (
+	"sync"
+)
+
+var (
+	eventObserversMutex sync.Mutex
+	eventObservers      []func(name string, details any)
+)
+
+// RegisterEventObserver is called by the assert package to see every event sent by lifecycle.SendEvent
+func RegisterEventObserver(observer func(name string, details any)) {
+	eventObserversMutex.Lock()
+	defer eventObserversMutex.Unlock()
+	eventObservers = append(eventObservers, observer)
+}
+
+// ObserveEvent passes an event to every registered observer
+func ObserveEvent(name string, details any) {
+	eventObserversMutex.Lock()
+	observers := eventObservers
+	eventObserversMutex.Unlock()
+	for _, observer := range observers {
+		observer(name, details)
+	}
+}
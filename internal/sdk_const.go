@@ -10,3 +10,11 @@ const Protocol_Version = "1.1.0"
 // Environment Vars
 // --------------------------------------------------------------------------------
 const localOutputEnvVar = "ANTITHESIS_SDK_LOCAL_OUTPUT"
+
+// When set to a non-empty value, the assert package reports a summary
+// of every property when the process is interrupted or terminated.
+const LocalSummaryEnvVar = "ANTITHESIS_SDK_LOCAL_SUMMARY"
+
+// Set in the environment of the process started by assert.EnableCrashReports,
+// which monitors its parent for crashes.
+const CrashMonitorEnvVar = "ANTITHESIS_SDK_CRASH_MONITOR"
@@ -0,0 +1,25 @@
+//go:build !no_antithesis_sdk
+
+package internal
+
+import (
+	"sync"
+)
+
+var (
+	setupComplete     = make(chan struct{})
+	setupCompleteOnce sync.Once
+)
+
+// MarkSetupComplete is called by lifecycle.SetupComplete, to release
+// anything waiting on SetupCompleted. Only the first call has any effect.
+func MarkSetupComplete() {
+	setupCompleteOnce.Do(func() {
+		close(setupComplete)
+	})
+}
+
+// SetupCompleted returns a channel which is closed once setup has completed
+func SetupCompleted() <-chan struct{} {
+	return setupComplete
+}
@@ -0,0 +1,82 @@
+//go:build !no_antithesis_sdk
+
+package internal
+
+import (
+	"sync/atomic"
+)
+
+// OutputHandler is the shape of a user supplied sink for the JSON messages
+// produced by the SDK. It is satisfied by sdk.Handler.
+type OutputHandler interface {
+	Output(message string)
+}
+
+// randomHandler may optionally be implemented by an OutputHandler which
+// also wants to supply the values returned by Get_random.
+type randomHandler interface {
+	Random() uint64
+}
+
+// outputHandlerBox exists because atomic.Pointer needs a concrete type
+type outputHandlerBox struct {
+	h OutputHandler
+}
+
+// When nil, output goes to the libHandler selected in init()
+var outputHandler atomic.Pointer[outputHandlerBox]
+
+// defaultOutputHandler routes output to the libHandler selected in init()
+type defaultOutputHandler struct{}
+
+func (defaultOutputHandler) Output(message string) {
+	handler.output(message)
+}
+
+func (defaultOutputHandler) Random() uint64 {
+	return handler.random()
+}
+
+// DefaultOutputHandler returns the handler chosen at startup: libvoidstar
+// when running in Antithesis, otherwise the local handler.
+func DefaultOutputHandler() OutputHandler {
+	return defaultOutputHandler{}
+}
+
+// SetOutputHandler replaces the destination of all JSON output.
+// Passing nil restores the default handler.
+func SetOutputHandler(h OutputHandler) {
+	if h == nil {
+		outputHandler.Store(nil)
+		return
+	}
+	outputHandler.Store(&outputHandlerBox{h})
+}
+
+// CurrentOutputHandler returns the handler that JSON output is currently sent to.
+func CurrentOutputHandler() OutputHandler {
+	if box := outputHandler.Load(); box != nil {
+		return box.h
+	}
+	return DefaultOutputHandler()
+}
+
+func emitOutput(message string) {
+	if crash_monitor_muted.Load() {
+		return
+	}
+	if box := outputHandler.Load(); box != nil {
+		box.h.Output(message)
+		return
+	}
+	handler.output(message)
+}
+
+func emitRandom() uint64 {
+	if box := outputHandler.Load(); box != nil {
+		if r, ok := box.h.(randomHandler); ok {
+			return r.Random()
+		}
+	}
+	return handler.random()
+}
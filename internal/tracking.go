@@ -0,0 +1,67 @@
+//go:build !no_antithesis_sdk
+
+package internal
+
+import (
+	"sync"
+)
+
+// Property is a snapshot of what the assert package has tracked for a
+// single assertion message. It allows helper packages within this module
+// to inspect assertion state without widening the assert package API.
+type Property struct {
+	Message     string
+	AssertType  string
+	DisplayType string
+	Classname   string
+	Funcname    string
+	Filename    string
+	Line        int
+	MustHit     bool
+	Registered  bool // true when the property was registered by the assertion catalog
+	PassCount   int
+	FailCount   int
+}
+
+var (
+	trackingMutex    sync.Mutex
+	propertiesSource func() []Property
+	resetHooks       []func()
+)
+
+// RegisterPropertiesSource is called by the assert package to make its tracker visible to Properties
+func RegisterPropertiesSource(source func() []Property) {
+	trackingMutex.Lock()
+	defer trackingMutex.Unlock()
+	propertiesSource = source
+}
+
+// Properties returns a snapshot of every assertion property tracked so far
+func Properties() []Property {
+	trackingMutex.Lock()
+	source := propertiesSource
+	trackingMutex.Unlock()
+	if source == nil {
+		return nil
+	}
+	return source()
+}
+
+// RegisterResetHook adds a function to be called by ResetTrackers
+func RegisterResetHook(hook func()) {
+	trackingMutex.Lock()
+	defer trackingMutex.Unlock()
+	resetHooks = append(resetHooks, hook)
+}
+
+// ResetTrackers forgets the pass/fail history of every assertion, so that the
+// next evaluation of each assertion is emitted again. Properties registered by
+// the assertion catalog are retained.
+func ResetTrackers() {
+	trackingMutex.Lock()
+	hooks := append([]func(){}, resetHooks...)
+	trackingMutex.Unlock()
+	for _, hook := range hooks {
+		hook()
+	}
+}
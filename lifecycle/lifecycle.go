@@ -17,20 +17,30 @@ import (
 //
 // Calling this function multiple times or from multiple processes will have no effect. Antithesis will treat the first time any process called this function as the moment that the setup was completed.
 //
+// The deadlines of [assert.Eventually] assertions in this process only start once this function has been called.
+//
 // [injecting faults]: https://antithesis.com/docs/environment/fault_injection/
+// [assert.Eventually]: https://pkg.go.dev/github.com/antithesishq/antithesis-sdk-go/assert#Eventually
 func SetupComplete(details any) {
 	statusBlock := map[string]any{
 		"status":  "complete",
-		"details": details,
+		"details": internal.NormalizeDetails(details),
 	}
 	internal.Json_data(map[string]any{"antithesis_setup": statusBlock})
+	internal.MarkSetupComplete()
 }
 
 // SendEvent indicates to Antithesis that a certain event has been reached. It provides greater information about the ordering of events during the course of testing in Antithesis.
 //
 // In addition to details, you also provide an eventName, which is the name of the event that you are logging. This name will appear in the logs section of a [triage report].
 //
+// Details are redacted as configured with [assert.RedactKeys] and [assert.RedactValues] before they are sent. Events are also checked against the ordering properties declared with [assert.AlwaysHappensBefore], as they are sent.
+//
 // [triage report]: https://antithesis.com/docs/reports/
+// [assert.AlwaysHappensBefore]: https://pkg.go.dev/github.com/antithesishq/antithesis-sdk-go/assert#AlwaysHappensBefore
+// [assert.RedactKeys]: https://pkg.go.dev/github.com/antithesishq/antithesis-sdk-go/assert#RedactKeys
+// [assert.RedactValues]: https://pkg.go.dev/github.com/antithesishq/antithesis-sdk-go/assert#RedactValues
 func SendEvent(eventName string, details any) {
-	internal.Json_data(map[string]any{eventName: details})
+	internal.Json_data(map[string]any{eventName: internal.NormalizeDetails(details)})
+	internal.ObserveEvent(eventName, details)
 }
@@ -1,10 +1,13 @@
 #! /bin/sh
 set -e
 go fmt -x github.com/antithesishq/antithesis-sdk-go/assert
+go fmt -x github.com/antithesishq/antithesis-sdk-go/assert/asserttesting
 go fmt -x github.com/antithesishq/antithesis-sdk-go/instrumentation
 go fmt -x github.com/antithesishq/antithesis-sdk-go/internal
 go fmt -x github.com/antithesishq/antithesis-sdk-go/lifecycle
 go fmt -x github.com/antithesishq/antithesis-sdk-go/random
+go fmt -x github.com/antithesishq/antithesis-sdk-go/sdk
+go fmt -x github.com/antithesishq/antithesis-sdk-go/sdk/recorder
 
 go fmt -x github.com/antithesishq/antithesis-sdk-go/tools/antithesis-go-instrumentor
 go fmt -x github.com/antithesishq/antithesis-sdk-go/tools/antithesis-go-instrumentor/cmd
@@ -17,5 +20,6 @@ go build github.com/antithesishq/antithesis-sdk-go/lifecycle
 go build github.com/antithesishq/antithesis-sdk-go/internal
 go build github.com/antithesishq/antithesis-sdk-go/random
 go build github.com/antithesishq/antithesis-sdk-go/instrumentation
+go build github.com/antithesishq/antithesis-sdk-go/sdk
 
 go install tools/antithesis-go-instrumentor/*.go
@@ -0,0 +1,44 @@
+//go:build !no_antithesis_sdk
+
+// Package sdk controls where the output of the [Antithesis Go SDK] is sent. It is part of the [Antithesis Go SDK], which enables Go applications to integrate with the [Antithesis platform].
+//
+// By default, output is sent to the Antithesis platform when running in the Antithesis environment. Outside of Antithesis, output is written to the file named by the environment variable ANTITHESIS_SDK_LOCAL_OUTPUT, or discarded if that variable is not set. SetHandler replaces this destination, so that assertions, guidance and lifecycle events can be routed to your own collectors. Use MultiHandler together with DefaultHandler to keep the default destination while also sending output elsewhere.
+//
+// [Antithesis Go SDK]: https://antithesis.com/docs/using_antithesis/sdk/go/
+// [Antithesis platform]: https://antithesis.com
+package sdk
+
+import (
+	"github.com/antithesishq/antithesis-sdk-go/internal"
+)
+
+// SetHandler sends all subsequent SDK output to h. Passing nil restores the default handler.
+func SetHandler(h Handler) {
+	internal.SetOutputHandler(h)
+}
+
+// CurrentHandler returns the Handler that SDK output is currently sent to.
+func CurrentHandler() Handler {
+	return internal.CurrentOutputHandler()
+}
+
+// DefaultHandler returns the Handler selected when the program started: the Antithesis platform when running in Antithesis, otherwise the local output file (if any).
+//
+// The returned Handler implements Randomizer.
+func DefaultHandler() Handler {
+	return internal.DefaultOutputHandler()
+}
+
+// SetErrorHandler calls h with every subsequent failure to emit output, such as details that cannot be marshaled to JSON or a failed write to the local output file. Passing nil removes the handler. h may be called concurrently from multiple goroutines.
+//
+// h is called synchronously, on the goroutine whose output failed, so it must not block: the code that made the assertion or sent the event waits for it to return. Like a Handler, h is never called while the SDK holds a lock, so it may itself make assertions. To do anything slow, such as sending the error over the network, hand it off to another goroutine.
+//
+// Failures are also counted by ErrorCount, and the first one is logged to standard error. An assertion whose details cannot be emitted is still emitted, with its details replaced by a description of the failure, so that its test property is not lost.
+func SetErrorHandler(h func(err error)) {
+	internal.SetEmitErrorHandler(h)
+}
+
+// ErrorCount returns the number of failures to emit output since the program started.
+func ErrorCount() uint64 {
+	return internal.EmitErrors()
+}
@@ -0,0 +1,14 @@
+//go:build no_antithesis_sdk
+
+package sdk
+
+type discardHandler struct{}
+
+func (discardHandler) Output(message string) {}
+
+func SetHandler(h Handler)    {}
+func CurrentHandler() Handler { return discardHandler{} }
+func DefaultHandler() Handler { return discardHandler{} }
+
+func SetErrorHandler(h func(err error)) {}
+func ErrorCount() uint64                { return 0 }
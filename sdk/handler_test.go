@@ -0,0 +1,113 @@
+//go:build !no_antithesis_sdk
+
+package sdk
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	"github.com/antithesishq/antithesis-sdk-go/lifecycle"
+	"github.com/antithesishq/antithesis-sdk-go/random"
+)
+
+type collector struct {
+	messages []string
+}
+
+func (c *collector) Output(message string) {
+	c.messages = append(c.messages, message)
+}
+
+type fixedRandom struct {
+	collector
+	value uint64
+}
+
+func (f *fixedRandom) Random() uint64 {
+	return f.value
+}
+
+func TestSetHandler(t *testing.T) {
+	c := &collector{}
+	SetHandler(c)
+	defer SetHandler(nil)
+
+	lifecycle.SendEvent("sdk_handler_test", map[string]any{"n": 1})
+	if len(c.messages) != 1 {
+		t.Fatalf("got %d messages, want 1", len(c.messages))
+	}
+	var got map[string]map[string]int
+	if err := json.Unmarshal([]byte(c.messages[0]), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got["sdk_handler_test"]["n"] != 1 {
+		t.Fatalf("unexpected message %s", c.messages[0])
+	}
+	if CurrentHandler() != Handler(c) {
+		t.Fatalf("CurrentHandler did not return the installed handler")
+	}
+}
+
+func TestSetHandlerNilRestoresDefault(t *testing.T) {
+	SetHandler(&collector{})
+	SetHandler(nil)
+	if CurrentHandler() != DefaultHandler() {
+		t.Fatalf("CurrentHandler is not the default handler after SetHandler(nil)")
+	}
+}
+
+func TestMultiHandler(t *testing.T) {
+	a, b := &collector{}, &collector{}
+	SetHandler(MultiHandler(a, nil, b))
+	defer SetHandler(nil)
+
+	lifecycle.SendEvent("first", nil)
+	lifecycle.SendEvent("second", nil)
+	for _, c := range []*collector{a, b} {
+		if len(c.messages) != 2 {
+			t.Fatalf("got %d messages, want 2", len(c.messages))
+		}
+	}
+}
+
+func TestHandlerFunc(t *testing.T) {
+	n := 0
+	SetHandler(HandlerFunc(func(string) { n++ }))
+	defer SetHandler(nil)
+
+	lifecycle.SendEvent("counted", nil)
+	if n != 1 {
+		t.Fatalf("HandlerFunc called %d times, want 1", n)
+	}
+}
+
+func TestRandomizer(t *testing.T) {
+	SetHandler(&fixedRandom{value: 42})
+	defer SetHandler(nil)
+
+	r := rand.New(random.Source())
+	for i := 0; i < 10; i++ {
+		if got := r.Uint64(); got != 42 {
+			t.Fatalf("Uint64() = %d, want 42", got)
+		}
+	}
+}
+
+func TestErrorHandler(t *testing.T) {
+	c := &collector{}
+	SetHandler(c)
+	defer SetHandler(nil)
+	var reported []error
+	SetErrorHandler(func(err error) { reported = append(reported, err) })
+	defer SetErrorHandler(nil)
+	before := ErrorCount()
+
+	lifecycle.SendEvent("unmarshalable", map[string]any{"f": func() {}})
+	if len(c.messages) != 0 {
+		t.Fatalf("emitted %v", c.messages)
+	}
+	if len(reported) != 1 || ErrorCount() != before+1 {
+		t.Fatalf("reported %v, ErrorCount increased by %d", reported, ErrorCount()-before)
+	}
+}
@@ -0,0 +1,44 @@
+package sdk
+
+// Handler receives every JSON message produced by the assert and lifecycle packages. Each message is a single, complete JSON object without a trailing newline.
+//
+// Output may be called concurrently from multiple goroutines, so implementations must be safe for concurrent use.
+//
+// Output is never called while the SDK holds a lock, so it may itself evaluate assertions or send lifecycle events. The messages they produce are passed to Output in turn, so a Handler must not produce output for every message it receives.
+type Handler interface {
+	Output(message string)
+}
+
+// Randomizer may optionally be implemented by a Handler. When the installed Handler implements Randomizer, the random package obtains its values from Random instead of from the default source.
+type Randomizer interface {
+	Random() uint64
+}
+
+// HandlerFunc adapts an ordinary function to the Handler interface.
+type HandlerFunc func(message string)
+
+// Output calls f(message).
+func (f HandlerFunc) Output(message string) {
+	f(message)
+}
+
+type multiHandler []Handler
+
+func (m multiHandler) Output(message string) {
+	for _, h := range m {
+		h.Output(message)
+	}
+}
+
+// MultiHandler returns a Handler that forwards every message to each of handlers, in order. Nil handlers are skipped.
+//
+// The returned Handler does not implement Randomizer, even when some of handlers do.
+func MultiHandler(handlers ...Handler) Handler {
+	m := make(multiHandler, 0, len(handlers))
+	for _, h := range handlers {
+		if h != nil {
+			m = append(m, h)
+		}
+	}
+	return m
+}
@@ -0,0 +1,236 @@
+// Package recorder captures the output of the [Antithesis Go SDK] in memory, so that unit tests can check which assertions, guidance and lifecycle events their code produces. It is part of the [Antithesis Go SDK], which enables Go applications to integrate with the [Antithesis platform].
+//
+// A typical test starts a Recorder, exercises the code under test, and then queries what was recorded:
+//
+//	func TestRetry(t *testing.T) {
+//		rec := recorder.New()
+//		rec.Start()
+//		defer rec.Stop()
+//
+//		callWithRetry()
+//
+//		if !rec.Reached("retry succeeded") {
+//			t.Error("retry path was not taken")
+//		}
+//	}
+//
+// The assert package only emits the first passing and the first failing evaluation of each assertion. Start resets that record so that each test observes the assertions it triggers, and Counts reports how many times each assertion was evaluated, including the evaluations which were not emitted.
+//
+// [Antithesis Go SDK]: https://antithesis.com/docs/using_antithesis/sdk/go/
+// [Antithesis platform]: https://antithesis.com
+package recorder
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/antithesishq/antithesis-sdk-go/sdk"
+)
+
+// Location identifies where an assertion or guidance was made.
+type Location struct {
+	Classname string `json:"class"`
+	Funcname  string `json:"function"`
+	Filename  string `json:"file"`
+	Line      int    `json:"begin_line"`
+	Column    int    `json:"begin_column"`
+}
+
+// Assertion is an emitted assertion. Details are decoded from JSON, so numbers appear as float64.
+type Assertion struct {
+	Location    Location       `json:"location"`
+	Details     map[string]any `json:"details"`
+	AssertType  string         `json:"assert_type"`
+	DisplayType string         `json:"display_type"`
+	Message     string         `json:"message"`
+	Id          string         `json:"id"`
+	Hit         bool           `json:"hit"`
+	MustHit     bool           `json:"must_hit"`
+	Condition   bool           `json:"condition"`
+}
+
+// Guidance is emitted numeric, boolean or exploration guidance. Data holds the undecoded guidance_data.
+type Guidance struct {
+	Location     Location        `json:"location"`
+	Data         json.RawMessage `json:"guidance_data"`
+	GuidanceType string          `json:"guidance_type"`
+	Message      string          `json:"message"`
+	Id           string          `json:"id"`
+	Maximize     bool            `json:"maximize"`
+	Hit          bool            `json:"hit"`
+}
+
+// Event is a lifecycle event. SetupComplete is recorded as an event named "antithesis_setup".
+type Event struct {
+	Name    string
+	Details any
+}
+
+// Recorder is an [sdk.Handler] that keeps everything it receives in memory. It is safe for concurrent use.
+type Recorder struct {
+	mutex      sync.Mutex
+	assertions []Assertion
+	guidance   []Guidance
+	events     []Event
+	previous   sdk.Handler
+	started    bool
+}
+
+// New returns an empty Recorder. It does not receive any output until Start is called, or it is passed to [sdk.SetHandler].
+func New() *Recorder {
+	return &Recorder{}
+}
+
+// Start resets the SDK's assertion and guidance trackers, then adds r to the current SDK handler, so output continues to reach its existing destination as well.
+func (r *Recorder) Start() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.started {
+		return
+	}
+	ResetTrackers()
+	r.previous = sdk.CurrentHandler()
+	sdk.SetHandler(sdk.MultiHandler(r.previous, r))
+	r.started = true
+}
+
+// Stop restores the SDK handler that was in place when Start was called. Recorded output remains available.
+func (r *Recorder) Stop() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if !r.started {
+		return
+	}
+	sdk.SetHandler(r.previous)
+	r.previous = nil
+	r.started = false
+}
+
+// Output records a single JSON message. It implements [sdk.Handler].
+func (r *Recorder) Output(message string) {
+	var wrapped map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(message), &wrapped); err != nil {
+		return
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for name, body := range wrapped {
+		switch name {
+		case "antithesis_sdk":
+			// The SDK version message is not interesting to tests
+		case "antithesis_assert":
+			var a Assertion
+			if err := json.Unmarshal(body, &a); err == nil {
+				r.assertions = append(r.assertions, a)
+			}
+		case "antithesis_guidance":
+			var g Guidance
+			if err := json.Unmarshal(body, &g); err == nil {
+				r.guidance = append(r.guidance, g)
+			}
+		default:
+			var details any
+			if err := json.Unmarshal(body, &details); err == nil {
+				r.events = append(r.events, Event{name, details})
+			}
+		}
+	}
+}
+
+// Clear discards everything recorded so far.
+func (r *Recorder) Clear() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.assertions = nil
+	r.guidance = nil
+	r.events = nil
+}
+
+// Assertions returns every assertion recorded, in the order they were emitted.
+func (r *Recorder) Assertions() []Assertion {
+	return r.filterAssertions(func(Assertion) bool { return true })
+}
+
+// AssertionsFor returns the recorded assertions with the given message.
+func (r *Recorder) AssertionsFor(message string) []Assertion {
+	return r.filterAssertions(func(a Assertion) bool { return a.Message == message })
+}
+
+// AssertionsByDisplayType returns the recorded assertions of one kind, such as "Always", "Sometimes" or "Reachable".
+func (r *Recorder) AssertionsByDisplayType(displayType string) []Assertion {
+	return r.filterAssertions(func(a Assertion) bool { return a.DisplayType == displayType })
+}
+
+// Failures returns the recorded assertions which were hit and whose condition did not hold, for every kind of assertion except Sometimes.
+func (r *Recorder) Failures() []Assertion {
+	return r.filterAssertions(func(a Assertion) bool {
+		return a.Hit && !a.Condition && a.AssertType != "sometimes"
+	})
+}
+
+// Reached reports whether an assertion with the given message was evaluated with a true condition.
+func (r *Recorder) Reached(message string) bool {
+	for _, a := range r.AssertionsFor(message) {
+		if a.Hit && a.Condition {
+			return true
+		}
+	}
+	return false
+}
+
+// Guidance returns every guidance record, in the order it was emitted.
+func (r *Recorder) Guidance() []Guidance {
+	return r.filterGuidance(func(Guidance) bool { return true })
+}
+
+// GuidanceFor returns the guidance records with the given message.
+func (r *Recorder) GuidanceFor(message string) []Guidance {
+	return r.filterGuidance(func(g Guidance) bool { return g.Message == message })
+}
+
+// Events returns every lifecycle event, in the order it was sent.
+func (r *Recorder) Events() []Event {
+	return r.filterEvents(func(Event) bool { return true })
+}
+
+// EventsNamed returns the lifecycle events with the given name.
+func (r *Recorder) EventsNamed(name string) []Event {
+	return r.filterEvents(func(e Event) bool { return e.Name == name })
+}
+
+func (r *Recorder) filterAssertions(keep func(Assertion) bool) []Assertion {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	var out []Assertion
+	for _, a := range r.assertions {
+		if keep(a) {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+func (r *Recorder) filterGuidance(keep func(Guidance) bool) []Guidance {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	var out []Guidance
+	for _, g := range r.guidance {
+		if keep(g) {
+			out = append(out, g)
+		}
+	}
+	return out
+}
+
+func (r *Recorder) filterEvents(keep func(Event) bool) []Event {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	var out []Event
+	for _, e := range r.events {
+		if keep(e) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
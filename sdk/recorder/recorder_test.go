@@ -0,0 +1,77 @@
+//go:build !no_antithesis_sdk
+
+package recorder
+
+import (
+	"testing"
+
+	"github.com/antithesishq/antithesis-sdk-go/assert"
+	"github.com/antithesishq/antithesis-sdk-go/lifecycle"
+)
+
+func TestRecordAssertions(t *testing.T) {
+	rec := New()
+	rec.Start()
+	defer rec.Stop()
+
+	for i := 0; i < 3; i++ {
+		assert.Always(i < 2, "recorder always", map[string]any{"i": i})
+	}
+	assert.Reachable("recorder reachable", nil)
+
+	if got := len(rec.AssertionsFor("recorder always")); got != 2 {
+		t.Fatalf("recorded %d Always assertions, want 2 (first pass and first fail)", got)
+	}
+	if passed, failed := rec.Counts("recorder always"); passed != 2 || failed != 1 {
+		t.Fatalf("Counts() = %d, %d, want 2, 1", passed, failed)
+	}
+	failures := rec.Failures()
+	if len(failures) != 1 || failures[0].Details["i"] != float64(2) {
+		t.Fatalf("unexpected failures %+v", failures)
+	}
+	if !rec.Reached("recorder reachable") {
+		t.Fatalf("Reachable was not recorded")
+	}
+	if got := len(rec.AssertionsByDisplayType("Reachable")); got != 1 {
+		t.Fatalf("recorded %d Reachable assertions, want 1", got)
+	}
+}
+
+func TestStartResetsTrackers(t *testing.T) {
+	for i := 0; i < 2; i++ {
+		rec := New()
+		rec.Start()
+		assert.Sometimes(true, "recorder repeated", nil)
+		assert.AlwaysGreaterThan(2, 1, "recorder guidance", nil)
+		rec.Stop()
+
+		if !rec.Reached("recorder repeated") {
+			t.Fatalf("attempt %d: assertion was not recorded", i)
+		}
+		if len(rec.GuidanceFor("recorder guidance")) != 1 {
+			t.Fatalf("attempt %d: guidance was not recorded", i)
+		}
+	}
+}
+
+func TestRecordEvents(t *testing.T) {
+	rec := New()
+	rec.Start()
+	defer rec.Stop()
+
+	lifecycle.SetupComplete(nil)
+	lifecycle.SendEvent("recorder event", map[string]any{"key": "value"})
+
+	if len(rec.EventsNamed("antithesis_setup")) != 1 {
+		t.Fatalf("SetupComplete was not recorded: %+v", rec.Events())
+	}
+	events := rec.EventsNamed("recorder event")
+	if len(events) != 1 || events[0].Details.(map[string]any)["key"] != "value" {
+		t.Fatalf("unexpected events %+v", events)
+	}
+
+	rec.Clear()
+	if len(rec.Events()) != 0 {
+		t.Fatalf("Clear did not discard events")
+	}
+}
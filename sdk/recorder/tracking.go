@@ -0,0 +1,22 @@
+//go:build !no_antithesis_sdk
+
+package recorder
+
+import (
+	"github.com/antithesishq/antithesis-sdk-go/internal"
+)
+
+// ResetTrackers makes the SDK forget which assertions and guidance it has already emitted, so that the next evaluation of each is emitted again. Properties registered by the assertion catalog stay registered.
+func ResetTrackers() {
+	internal.ResetTrackers()
+}
+
+// Counts returns how many times the assertion with the given message passed and failed since the SDK trackers were last reset. Unlike the recorded assertions, these counts include evaluations which were not emitted.
+func (r *Recorder) Counts(message string) (passed, failed int) {
+	for _, p := range internal.Properties() {
+		if p.Message == message {
+			return p.PassCount, p.FailCount
+		}
+	}
+	return 0, 0
+}
@@ -20,6 +20,7 @@ const (
 type AssertionFuncInfo struct {
 	TargetFunc string
 	AssertType string
+	BaseFunc   string // The basic assertion reported at runtime, when TargetFunc is a rich assertion
 	MustHit    bool
 	Condition  bool
 	MessageArg int
@@ -70,6 +71,147 @@ func SetupHintMap() AssertionHints {
 		MessageArg: 0,
 	}
 
+	hintMap["AlwaysEqual"] = &AssertionFuncInfo{
+		TargetFunc: "AlwaysEqual",
+		BaseFunc:   "Always",
+		MustHit:    true,
+		AssertType: "always",
+		Condition:  false,
+		MessageArg: 2,
+	}
+
+	hintMap["SometimesEqual"] = &AssertionFuncInfo{
+		TargetFunc: "SometimesEqual",
+		BaseFunc:   "Sometimes",
+		MustHit:    true,
+		AssertType: "sometimes",
+		Condition:  false,
+		MessageArg: 2,
+	}
+
+	hintMap["AlwaysDeepEqual"] = &AssertionFuncInfo{
+		TargetFunc: "AlwaysDeepEqual",
+		BaseFunc:   "Always",
+		MustHit:    true,
+		AssertType: "always",
+		Condition:  false,
+		MessageArg: 2,
+	}
+
+	hintMap["SometimesDeepEqual"] = &AssertionFuncInfo{
+		TargetFunc: "SometimesDeepEqual",
+		BaseFunc:   "Sometimes",
+		MustHit:    true,
+		AssertType: "sometimes",
+		Condition:  false,
+		MessageArg: 2,
+	}
+
+	hintMap["AlwaysNoError"] = &AssertionFuncInfo{
+		TargetFunc: "AlwaysNoError",
+		BaseFunc:   "Always",
+		MustHit:    true,
+		AssertType: "always",
+		Condition:  false,
+		MessageArg: 1,
+	}
+
+	hintMap["SometimesError"] = &AssertionFuncInfo{
+		TargetFunc: "SometimesError",
+		BaseFunc:   "Sometimes",
+		MustHit:    true,
+		AssertType: "sometimes",
+		Condition:  false,
+		MessageArg: 1,
+	}
+
+	hintMap["SometimesErrorIs"] = &AssertionFuncInfo{
+		TargetFunc: "SometimesErrorIs",
+		BaseFunc:   "Sometimes",
+		MustHit:    true,
+		AssertType: "sometimes",
+		Condition:  false,
+		MessageArg: 2,
+	}
+
+	hintMap["AlwaysErrorAs"] = &AssertionFuncInfo{
+		TargetFunc: "AlwaysErrorAs",
+		BaseFunc:   "Always",
+		MustHit:    true,
+		AssertType: "always",
+		Condition:  false,
+		MessageArg: 2,
+	}
+
+	hintMap["Eventually"] = &AssertionFuncInfo{
+		TargetFunc: "Eventually",
+		BaseFunc:   "Always",
+		MustHit:    true,
+		AssertType: "always",
+		Condition:  false,
+		MessageArg: 0,
+	}
+
+	hintMap["RegisterInvariant"] = &AssertionFuncInfo{
+		TargetFunc: "RegisterInvariant",
+		BaseFunc:   "Always",
+		MustHit:    true,
+		AssertType: "always",
+		Condition:  false,
+		MessageArg: 0,
+	}
+
+	hintMap["AlwaysUnique"] = &AssertionFuncInfo{
+		TargetFunc: "AlwaysUnique",
+		BaseFunc:   "Always",
+		MustHit:    true,
+		AssertType: "always",
+		Condition:  false,
+		MessageArg: 2,
+	}
+
+	hintMap["AlwaysHappensBefore"] = &AssertionFuncInfo{
+		TargetFunc: "AlwaysHappensBefore",
+		BaseFunc:   "Always",
+		MustHit:    true,
+		AssertType: "always",
+		Condition:  false,
+		MessageArg: 3,
+	}
+
+	hintMap["RecoverAndReport"] = &AssertionFuncInfo{
+		TargetFunc: "RecoverAndReport",
+		BaseFunc:   "Unreachable",
+		MustHit:    false,
+		AssertType: "reachability",
+		Condition:  false,
+		MessageArg: 0,
+	}
+
+	hintMap["ReportPanic"] = &AssertionFuncInfo{
+		TargetFunc: "ReportPanic",
+		BaseFunc:   "Unreachable",
+		MustHit:    false,
+		AssertType: "reachability",
+		Condition:  false,
+		MessageArg: 0,
+	}
+
+	hintMap["Go"] = &AssertionFuncInfo{
+		TargetFunc: "Go",
+		BaseFunc:   "Unreachable",
+		MustHit:    false,
+		AssertType: "reachability",
+		Condition:  false,
+		MessageArg: 0,
+	}
+
+	for _, name := range []string{"Always", "AlwaysOrUnreachable", "Sometimes", "Unreachable", "Reachable",
+		"AlwaysEqual", "SometimesEqual", "AlwaysDeepEqual", "SometimesDeepEqual",
+		"AlwaysNoError", "SometimesError", "SometimesErrorIs", "AlwaysErrorAs", "AlwaysUnique", "Eventually"} {
+		hintMap[name+"Ctx"] = contextVariant(hintMap[name])
+	}
+
 	return hintMap
 }
 
@@ -174,6 +316,72 @@ func SetupGuidanceHintMap() GuidanceHints {
 		GuidanceFn: GuidanceFnMinimize,
 	}
 
+	hintMap["AlwaysInRange"] = &GuidanceFuncInfo{
+		AssertionFuncInfo: AssertionFuncInfo{
+			TargetFunc: "AlwaysInRange",
+			AssertType: "always",
+			MustHit:    true,
+			Condition:  false,
+			MessageArg: 3,
+		},
+		GuidanceFn: GuidanceFnMinimize,
+	}
+
+	hintMap["SometimesInRange"] = &GuidanceFuncInfo{
+		AssertionFuncInfo: AssertionFuncInfo{
+			TargetFunc: "SometimesInRange",
+			AssertType: "sometimes",
+			MustHit:    true,
+			Condition:  false,
+			MessageArg: 3,
+		},
+		GuidanceFn: GuidanceFnMaximize,
+	}
+
+	hintMap["SometimesAtLeast"] = &GuidanceFuncInfo{
+		AssertionFuncInfo: AssertionFuncInfo{
+			TargetFunc: "SometimesAtLeast",
+			AssertType: "sometimes",
+			MustHit:    true,
+			Condition:  false,
+			MessageArg: 2,
+		},
+		GuidanceFn: GuidanceFnMaximize,
+	}
+
+	hintMap["AlwaysFractionBelow"] = &GuidanceFuncInfo{
+		AssertionFuncInfo: AssertionFuncInfo{
+			TargetFunc: "AlwaysFractionBelow",
+			AssertType: "always",
+			MustHit:    true,
+			Condition:  false,
+			MessageArg: 2,
+		},
+		GuidanceFn: GuidanceFnMaximize,
+	}
+
+	hintMap["AlwaysMonotonic"] = &GuidanceFuncInfo{
+		AssertionFuncInfo: AssertionFuncInfo{
+			TargetFunc: "AlwaysMonotonic",
+			AssertType: "always",
+			MustHit:    true,
+			Condition:  false,
+			MessageArg: 2,
+		},
+		GuidanceFn: GuidanceFnMinimize,
+	}
+
+	hintMap["AlwaysStrictlyMonotonic"] = &GuidanceFuncInfo{
+		AssertionFuncInfo: AssertionFuncInfo{
+			TargetFunc: "AlwaysStrictlyMonotonic",
+			AssertType: "always",
+			MustHit:    true,
+			Condition:  false,
+			MessageArg: 2,
+		},
+		GuidanceFn: GuidanceFnMinimize,
+	}
+
 	hintMap["AlwaysSome"] = &GuidanceFuncInfo{
 		AssertionFuncInfo: AssertionFuncInfo{
 			TargetFunc: "AlwaysSome",
@@ -196,9 +404,40 @@ func SetupGuidanceHintMap() GuidanceHints {
 		GuidanceFn: GuidanceFnWantAll,
 	}
 
+	hintMap["Explore"] = &GuidanceFuncInfo{
+		AssertionFuncInfo: AssertionFuncInfo{
+			TargetFunc: "Explore",
+			MustHit:    false,
+			Condition:  false,
+			MessageArg: 0,
+		},
+		GuidanceFn: GuidanceFnExplore,
+	}
+
+	for _, name := range []string{"AlwaysGreaterThan", "AlwaysGreaterThanOrEqualTo", "SometimesGreaterThan", "SometimesGreaterThanOrEqualTo",
+		"AlwaysLessThan", "AlwaysLessThanOrEqualTo", "SometimesLessThan", "SometimesLessThanOrEqualTo",
+		"AlwaysInRange", "SometimesInRange", "AlwaysSome", "SometimesAll",
+		"AlwaysMonotonic", "AlwaysStrictlyMonotonic", "SometimesAtLeast", "AlwaysFractionBelow"} {
+		variant := *hintMap[name]
+		variant.AssertionFuncInfo = *contextVariant(&variant.AssertionFuncInfo)
+		hintMap[name+"Ctx"] = &variant
+	}
+
 	return hintMap
 }
 
+// contextVariant describes the Ctx variant of an assertion, which takes a
+// context.Context before the arguments of the assertion
+func contextVariant(info *AssertionFuncInfo) *AssertionFuncInfo {
+	variant := *info
+	variant.TargetFunc = info.TargetFunc + "Ctx"
+	if variant.BaseFunc == "" {
+		variant.BaseFunc = info.TargetFunc
+	}
+	variant.MessageArg = info.MessageArg + 1
+	return &variant
+}
+
 func (m AssertionHints) HintsForName(name string) *AssertionFuncInfo {
 	if v, ok := m[name]; ok {
 		return v
@@ -78,6 +78,17 @@ func numericGuidance(guidance []*AntGuidance) []*AntGuidance {
 	return numeric_guidance
 }
 
+// filter Guidance to just explore
+func exploreGuidance(guidance []*AntGuidance) []*AntGuidance {
+	explore_guidance := []*AntGuidance{}
+	for _, aG := range guidance {
+		if aG.GuidanceFn == GuidanceFnExplore {
+			explore_guidance = append(explore_guidance, aG)
+		}
+	}
+	return explore_guidance
+}
+
 // filter Guidance to just boolean
 func booleanGuidance(guidance []*AntGuidance) []*AntGuidance {
 	boolean_guidance := []*AntGuidance{}
@@ -126,17 +137,20 @@ func (aScanner *AssertionScanner) WriteAssertionCatalogs(versionText string) {
 		expects := bc.expects
 		numericGuidance := numericGuidance(bc.guidance)
 		booleanGuidance := booleanGuidance(bc.guidance)
+		exploreGuidance := exploreGuidance(bc.guidance)
 
 		genInfo := GenInfo{
 			ExpectedVals:        expects,
 			NumericGuidanceVals: numericGuidance,
 			BooleanGuidanceVals: booleanGuidance,
+			ExploreGuidanceVals: exploreGuidance,
 			AssertPackageName:   common.AssertPackageName(),
 			VersionText:         versionText,
 			CreateDate:          createDate,
 			HasAssertions:       len(expects) > 0,
 			HasNumericGuidance:  len(numericGuidance) > 0,
 			HasBooleanGuidance:  len(booleanGuidance) > 0,
+			HasExploreGuidance:  len(exploreGuidance) > 0,
 			ConstMap:            getConstMap(expects),
 		}
 
@@ -306,8 +320,13 @@ func (aScanner *AssertionScanner) node_inspector(x ast.Node, pkg *packages.Packa
 					generated_msg := fmt.Sprintf("%s[%d]", relative_file_path, full_position.Line)
 					test_name = fmt.Sprintf("Message from %s", strconv.Quote(generated_msg))
 				}
+				// Rich assertions are reported at runtime as the basic assertion they are built on
+				assertion := target_func
+				if func_hints.BaseFunc != "" {
+					assertion = func_hints.BaseFunc
+				}
 				expect := AntExpect{
-					Assertion:         target_func,
+					Assertion:         assertion,
 					Message:           test_name,
 					Classname:         packageName,
 					Funcname:          funcName,
@@ -338,24 +357,27 @@ func (aScanner *AssertionScanner) node_inspector(x ast.Node, pkg *packages.Packa
 				}
 				data.guidance = append(data.guidance, &guidance_expect)
 
-				// The Related Assertion derived from target_func("AlwaysGreaterThan") => derived_target_func("Always")
-				expect := AntExpect{
-					Assertion: target_func_from_guidance(target_func),
-					Message:   test_name,
-					Classname: packageName,
-					Funcname:  funcName,
-					Receiver:  receiver,
-					Filename:  relative_file_path,
-					Line:      full_position.Line,
-					// NOTE: AssertionFuncInfo.TargetFunc is a guidance func name
-					// and AssertionFuncInfo.MessageArg refers to a Guidance Function argument number.
-					//
-					// GenerateAssertionsCatalog() does not use either of TargetFunc or MessageArg
-					// attributes of AssertionFuncInfo, so it is safe to pass the AssertionFuncInfo
-					// from the guidance func here.
-					AssertionFuncInfo: &guidance_func_hints.AssertionFuncInfo,
+				// Explore guidance has no related assertion
+				if guidance_func_hints.GuidanceFn != GuidanceFnExplore {
+					// The Related Assertion derived from target_func("AlwaysGreaterThan") => derived_target_func("Always")
+					expect := AntExpect{
+						Assertion: target_func_from_guidance(target_func),
+						Message:   test_name,
+						Classname: packageName,
+						Funcname:  funcName,
+						Receiver:  receiver,
+						Filename:  relative_file_path,
+						Line:      full_position.Line,
+						// NOTE: AssertionFuncInfo.TargetFunc is a guidance func name
+						// and AssertionFuncInfo.MessageArg refers to a Guidance Function argument number.
+						//
+						// GenerateAssertionsCatalog() does not use either of TargetFunc or MessageArg
+						// attributes of AssertionFuncInfo, so it is safe to pass the AssertionFuncInfo
+						// from the guidance func here.
+						AssertionFuncInfo: &guidance_func_hints.AssertionFuncInfo,
+					}
+					data.expects = append(data.expects, &expect)
 				}
-				data.expects = append(data.expects, &expect)
 			} // assertionHint
 		}
 	}
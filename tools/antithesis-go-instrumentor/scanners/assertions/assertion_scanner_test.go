@@ -98,6 +98,75 @@ func TestAliasedImport(t *testing.T) {
 	qt.Check(t, qt.SliceContains(msgs, "aliased unreachable"))
 }
 
+func TestRichAssertions(t *testing.T) {
+	dir := absTestdata("rich_assertions")
+	scanner := NewAssertionScanner(dir, dir)
+	err := scanner.ScanAll()
+	qt.Assert(t, qt.IsNil(err))
+
+	bins := scanner.binaries
+	qt.Assert(t, qt.HasLen(bins, 1))
+
+	// Rich assertions are cataloged as the basic assertion they report at runtime
+	assertions := make(map[string]string)
+	for _, e := range bins[0].expects {
+		assertions[e.Message] = e.Assertion
+	}
+	qt.Check(t, qt.Equals(assertions["always equal"], "Always"))
+	qt.Check(t, qt.Equals(assertions["sometimes equal"], "Sometimes"))
+	qt.Check(t, qt.Equals(assertions["always deep equal"], "Always"))
+	qt.Check(t, qt.Equals(assertions["sometimes deep equal"], "Sometimes"))
+	qt.Check(t, qt.Equals(assertions["always in range"], "Always"))
+	qt.Check(t, qt.Equals(assertions["sometimes in range"], "Sometimes"))
+	qt.Check(t, qt.Equals(assertions["sometimes at least"], "Sometimes"))
+	qt.Check(t, qt.Equals(assertions["always fraction below"], "Always"))
+	qt.Check(t, qt.Equals(assertions["always monotonic"], "Always"))
+	qt.Check(t, qt.Equals(assertions["always strictly monotonic"], "Always"))
+	qt.Check(t, qt.Equals(assertions["always no error"], "Always"))
+	qt.Check(t, qt.Equals(assertions["sometimes error"], "Sometimes"))
+	qt.Check(t, qt.Equals(assertions["sometimes error is"], "Sometimes"))
+	qt.Check(t, qt.Equals(assertions["always error as"], "Always"))
+	qt.Check(t, qt.Equals(assertions["always unique"], "Always"))
+	qt.Check(t, qt.Equals(assertions["eventually"], "Always"))
+	qt.Check(t, qt.Equals(assertions["invariant"], "Always"))
+	qt.Check(t, qt.Equals(assertions["happens before"], "Always"))
+	qt.Check(t, qt.Equals(assertions["go"], "Unreachable"))
+	qt.Check(t, qt.Equals(assertions["recover and report"], "Unreachable"))
+	qt.Check(t, qt.Equals(assertions["report panic"], "Unreachable"))
+
+	// The Ctx variants take a context before the arguments of the assertion
+	qt.Check(t, qt.Equals(assertions["always ctx"], "Always"))
+	qt.Check(t, qt.Equals(assertions["unreachable ctx"], "Unreachable"))
+	qt.Check(t, qt.Equals(assertions["sometimes equal ctx"], "Sometimes"))
+	qt.Check(t, qt.Equals(assertions["always less than ctx"], "Always"))
+	qt.Check(t, qt.Equals(assertions["sometimes all ctx"], "Sometimes"))
+	qt.Check(t, qt.Equals(assertions["always no error ctx"], "Always"))
+	qt.Check(t, qt.Equals(assertions["always unique ctx"], "Always"))
+	qt.Check(t, qt.Equals(assertions["always monotonic ctx"], "Always"))
+	qt.Check(t, qt.Equals(assertions["sometimes at least ctx"], "Sometimes"))
+	qt.Check(t, qt.Equals(assertions["always fraction below ctx"], "Always"))
+	qt.Check(t, qt.Equals(assertions["eventually ctx"], "Always"))
+
+	guidance := make(map[string]GuidanceFnType)
+	for _, g := range bins[0].guidance {
+		guidance[g.Message] = g.GuidanceFn
+	}
+	qt.Check(t, qt.Equals(guidance["always in range"], GuidanceFnMinimize))
+	qt.Check(t, qt.Equals(guidance["sometimes in range"], GuidanceFnMaximize))
+	qt.Check(t, qt.Equals(guidance["sometimes at least"], GuidanceFnMaximize))
+	qt.Check(t, qt.Equals(guidance["always fraction below"], GuidanceFnMaximize))
+	qt.Check(t, qt.Equals(guidance["always monotonic"], GuidanceFnMinimize))
+	qt.Check(t, qt.Equals(guidance["always less than ctx"], GuidanceFnMaximize))
+	qt.Check(t, qt.Equals(guidance["sometimes all ctx"], GuidanceFnWantAll))
+	qt.Check(t, qt.Equals(guidance["always monotonic ctx"], GuidanceFnMinimize))
+	qt.Check(t, qt.Equals(guidance["sometimes at least ctx"], GuidanceFnMaximize))
+
+	// Explore only provides guidance
+	qt.Check(t, qt.Equals(guidance["explore"], GuidanceFnExplore))
+	_, isAssertion := assertions["explore"]
+	qt.Check(t, qt.IsFalse(isAssertion))
+}
+
 func TestNoMain(t *testing.T) {
 	dir := absTestdata("no_main")
 	scanner := NewAssertionScanner(dir, dir)
@@ -19,9 +19,11 @@ type GenInfo struct {
 	ExpectedVals        []*AntExpect
 	NumericGuidanceVals []*AntGuidance
 	BooleanGuidanceVals []*AntGuidance
+	ExploreGuidanceVals []*AntGuidance
 	HasAssertions       bool
 	HasNumericGuidance  bool
 	HasBooleanGuidance  bool
+	HasExploreGuidance  bool
 }
 
 func IsGeneratedFile(file_name string) bool {
@@ -67,6 +69,10 @@ func booleanGuidanceNameRepr(s string) string {
 	return fmt.Sprintf("%s(pairs, message, details)", s)
 }
 
+func exploreGuidanceNameRepr(s string) string {
+	return fmt.Sprintf("%s(message, state)", s)
+}
+
 func hitRepr(b bool) string {
 	if !b {
 		return "notHit"
@@ -145,10 +151,11 @@ func GenerateAssertionsCatalog(outputDir string, genInfo *GenInfo) {
 		"textRepr":                textRepr,
 		"numericGuidanceNameRepr": numericGuidanceNameRepr,
 		"booleanGuidanceNameRepr": booleanGuidanceNameRepr,
+		"exploreGuidanceNameRepr": exploreGuidanceNameRepr,
 		"guidanceFnRepr":          guidanceFnRepr,
 	})
 
-	all_template_text := getExpectorText() + getNumericGuidanceText() + getBooleanGuidanceText()
+	all_template_text := getExpectorText() + getNumericGuidanceText() + getBooleanGuidanceText() + getExploreGuidanceText()
 	if tmpl, err = tmpl.Parse(all_template_text); err != nil {
 		panic(err)
 	}
@@ -176,7 +183,7 @@ package main
 // Generated on {{.CreateDate}}
 // ----------------------------------------------------
 
-{{if .HasAssertions -}}import "{{.AssertPackageName}}"{{- end}}
+{{if or .HasAssertions .HasExploreGuidance -}}import "{{.AssertPackageName}}"{{- end}}
 
 {{if .HasAssertions -}}
 func init() {
@@ -269,3 +276,28 @@ func init() {
 
 	return text
 }
+
+func getExploreGuidanceText() string {
+	const text = `
+
+{{if .HasExploreGuidance -}}
+func init() {
+
+  const notHit = false
+
+  {{- range .ExploreGuidanceVals }}
+  {{- $guidanceName := exploreGuidanceNameRepr .Assertion -}}
+	{{- $message := textRepr .Message -}}
+	{{- $classname := textRepr .Classname -}}
+	{{- $funcname := textRepr .Funcname -}}
+	{{- $filename := textRepr .Filename}}
+
+  // {{$guidanceName}}
+  assert.ExploreGuidanceRaw(nil, {{$message}}, {{$message}}, {{$classname}}, {{$funcname}}, {{$filename}}, {{.Line}}, notHit)
+  {{- end}}
+}
+{{- end}}
+`
+
+	return text
+}
@@ -124,3 +124,44 @@ func TestCatalogNumericGuidance(t *testing.T) {
 	qt.Check(t, qt.StringContains(text, "assert.NumericGuidanceRaw("))
 	qt.Check(t, qt.StringContains(text, `"x > y"`))
 }
+
+func TestCatalogExploreGuidance(t *testing.T) {
+	outputDir := t.TempDir()
+
+	exploreGuidance := []*AntGuidance{
+		{
+			GuidanceFuncInfo: &GuidanceFuncInfo{
+				AssertionFuncInfo: AssertionFuncInfo{
+					TargetFunc: "Explore",
+					MessageArg: 0,
+				},
+				GuidanceFn: GuidanceFnExplore,
+			},
+			Assertion: "Explore",
+			Message:   "cluster roles",
+			Classname: "example.com/mymod",
+			Funcname:  "observe",
+			Filename:  "observe.go",
+			Line:      7,
+		},
+	}
+
+	genInfo := GenInfo{
+		ExploreGuidanceVals: exploreGuidance,
+		AssertPackageName:   common.AssertPackageName(),
+		VersionText:         "test",
+		CreateDate:          "now",
+		HasExploreGuidance:  true,
+		ConstMap:            make(map[string]bool),
+	}
+
+	GenerateAssertionsCatalog(outputDir, &genInfo)
+
+	outputPath := filepath.Join(outputDir, common.GENERATED_CATALOG_FILE)
+	content, err := os.ReadFile(outputPath)
+	qt.Assert(t, qt.IsNil(err))
+
+	text := string(content)
+	qt.Check(t, qt.StringContains(text, `import "github.com/antithesishq/antithesis-sdk-go/assert"`))
+	qt.Check(t, qt.StringContains(text, `assert.ExploreGuidanceRaw(nil, "cluster roles", "cluster roles", "example.com/mymod", "observe", "observe.go", 7, notHit)`))
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"io/fs"
+	"time"
+
+	"github.com/antithesishq/antithesis-sdk-go/assert"
+)
+
+func main() {
+	assert.AlwaysEqual(1, 1, "always equal", nil)
+	assert.SometimesEqual("a", "a", "sometimes equal", nil)
+	assert.AlwaysDeepEqual([]int{1}, []int{1}, "always deep equal", nil)
+	assert.SometimesDeepEqual(map[string]int{}, map[string]int{}, "sometimes deep equal", nil)
+	assert.AlwaysInRange(5, 0, 10, "always in range", nil)
+	assert.SometimesInRange(0.5, 0.0, 1.0, "sometimes in range", nil)
+	assert.SometimesAtLeast(true, 5, "sometimes at least", nil)
+	assert.AlwaysFractionBelow(false, 0.01, "always fraction below", nil)
+	assert.AlwaysMonotonic("node", 1, "always monotonic", nil)
+	assert.AlwaysStrictlyMonotonic("key", uint64(1), "always strictly monotonic", nil)
+
+	var err error
+	var pathErr *fs.PathError
+	assert.AlwaysNoError(err, "always no error", nil)
+	assert.SometimesError(err, "sometimes error", nil)
+	assert.SometimesErrorIs(err, fs.ErrNotExist, "sometimes error is", nil)
+	assert.AlwaysErrorAs(err, &pathErr, "always error as", nil)
+
+	assert.AlwaysUnique("txn", "t-1", "always unique", nil)
+
+	ctx := assert.WithDetails(context.Background(), map[string]any{"request_id": "r-1"})
+	assert.AlwaysCtx(ctx, true, "always ctx", nil)
+	assert.UnreachableCtx(ctx, "unreachable ctx", nil)
+	assert.SometimesEqualCtx(ctx, 1, 1, "sometimes equal ctx", nil)
+	assert.AlwaysLessThanCtx(ctx, 1, 2, "always less than ctx", nil)
+	assert.SometimesAllCtx(ctx, []assert.NamedBool{{First: "a", Second: true}}, "sometimes all ctx", nil)
+	assert.AlwaysNoErrorCtx(ctx, nil, "always no error ctx", nil)
+	assert.AlwaysUniqueCtx(ctx, "ids", 1, "always unique ctx", nil)
+	assert.AlwaysMonotonicCtx(ctx, "key", 1, "always monotonic ctx", nil)
+	assert.SometimesAtLeastCtx(ctx, true, 2, "sometimes at least ctx", nil)
+	assert.AlwaysFractionBelowCtx(ctx, false, 0.5, "always fraction below ctx", nil)
+	assert.EventuallyCtx(ctx, "eventually ctx", time.Second, func() bool { return true }, nil)
+
+	assert.Explore("explore", map[string]any{"leaders": 1})
+
+	assert.Eventually("eventually", time.Second, func() bool { return true }, nil)
+	assert.AlwaysHappensBefore("write_acked", "read_observed", "key", "happens before", nil)
+	assert.Go("go", func() {}, nil)
+	defer assert.RecoverAndReport("recover and report", nil)
+	defer assert.ReportPanic("report panic", nil)
+	assert.RegisterInvariant("invariant", func() (bool, map[string]any) { return true, nil })
+}